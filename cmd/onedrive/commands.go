@@ -0,0 +1,519 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+const (
+	// simpleUploadLimit is the maximum size of the files which can be uploaded in a single request.
+	simpleUploadLimit = 4 * 1024 * 1024
+
+	// uploadFragmentSize is the size of the fragments of the larger files, a multiple of 320 KiB.
+	uploadFragmentSize = 32 * 320 * 1024
+)
+
+// errUsage is returned by the commands whose arguments are invalid, so that the usage is printed.
+var errUsage = errors.New("invalid arguments")
+
+var shareLinkTypes = map[string]onedrive.ShareLinkType{
+	"view":           onedrive.View,
+	"edit":           onedrive.Edit,
+	"embed":          onedrive.Embed,
+	"blocksDownload": onedrive.BlocksDownload,
+	"createOnly":     onedrive.CreateOnly,
+	"addressBar":     onedrive.AddressBar,
+	"adminDefault":   onedrive.AdminDefault,
+}
+
+var shareLinkScopes = map[string]onedrive.ShareLinkScope{
+	"anonymous":    onedrive.Anonymous,
+	"organization": onedrive.Organization,
+	"users":        onedrive.Users,
+}
+
+var lsCommand = &command{
+	usage:       "[folder]",
+	description: "list the items of a folder, by default the root folder",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) > 1 {
+				return errUsage
+			}
+
+			folder := ""
+			if len(args) == 1 {
+				folder = args[0]
+			}
+
+			var response *onedrive.OneDriveDriveItemsResponse
+			var err error
+			if folderId, ok := parseItemId(folder); ok {
+				response, err = a.client.DriveItems.ListInDrive(ctx, a.driveId, folderId)
+			} else {
+				response, err = a.client.DriveItems.ListByPath(ctx, a.driveId, folder)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return a.printItems(response.DriveItems)
+		}
+	},
+}
+
+var statCommand = &command{
+	usage:       "<item>",
+	description: "show the details of an item",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+
+			item, err := a.item(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.printItem(item)
+		}
+	},
+}
+
+var getCommand = &command{
+	usage:       "<file> [local path]",
+	description: "download a file to the local path, by default the name of the file in the current directory, or \"-\" for the standard output",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 1 && len(args) != 2 {
+				return errUsage
+			}
+
+			item, err := a.item(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if item.File == nil {
+				return fmt.Errorf("%q is not a file", args[0])
+			}
+
+			localPath := item.Name
+			if len(args) == 2 {
+				localPath = args[1]
+			}
+
+			content, err := a.client.DriveItems.DownloadStream(ctx, a.driveId, item.Id)
+			if err != nil {
+				return err
+			}
+			defer content.Close()
+
+			if localPath == "-" {
+				_, err = io.Copy(a.stdout, content)
+				return err
+			}
+
+			if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+				localPath = filepath.Join(localPath, item.Name)
+			}
+
+			file, err := os.Create(localPath)
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(file, content); err != nil {
+				file.Close()
+				return err
+			}
+
+			return file.Close()
+		}
+	},
+}
+
+var putCommand = &command{
+	usage:       "<local file> [folder]",
+	description: "upload a file to a folder, by default the root folder",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 1 && len(args) != 2 {
+				return errUsage
+			}
+
+			localPath, folder := args[0], ""
+			if len(args) == 2 {
+				folder = args[1]
+			}
+
+			info, err := os.Stat(localPath)
+			if err != nil {
+				return err
+			}
+
+			folderId, isId := parseItemId(folder)
+
+			var item *onedrive.DriveItem
+			switch {
+			case info.Size() > simpleUploadLimit:
+				if !isId {
+					folderItem, err := a.client.DriveItems.GetByPath(ctx, a.driveId, folder)
+					if err != nil {
+						return err
+					}
+
+					folderId = folderItem.Id
+				}
+
+				item, err = a.client.DriveItems.UploadNewFileLarge(ctx, a.driveId, folderId, localPath, uploadFragmentSize)
+			case isId:
+				item, err = a.client.DriveItems.UploadNewFile(ctx, a.driveId, folderId, localPath)
+			default:
+				item, err = a.client.DriveItems.UploadNewFileByPath(ctx, a.driveId, folder, localPath)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return a.printItem(item)
+		}
+	},
+}
+
+var mkdirCommand = &command{
+	usage:       "<folder path> | <parent folder> <name>",
+	description: "create a folder, given either its path or its parent folder and its name",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		return func(ctx context.Context, a *app, args []string) error {
+			var parent, name string
+			switch len(args) {
+			case 1:
+				if _, ok := parseItemId(args[0]); ok {
+					return errUsage
+				}
+
+				parent, name = path.Split(strings.Trim(args[0], "/"))
+			case 2:
+				parent, name = args[0], args[1]
+			default:
+				return errUsage
+			}
+
+			if name == "" {
+				return errUsage
+			}
+
+			var item *onedrive.DriveItem
+			var err error
+			if parentId, ok := parseItemId(parent); ok {
+				item, err = a.client.DriveItems.CreateNewFolder(ctx, a.driveId, parentId, name)
+			} else {
+				item, err = a.client.DriveItems.CreateFolderByPath(ctx, a.driveId, parent, name)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return a.printItem(item)
+		}
+	},
+}
+
+var rmCommand = &command{
+	usage:       "[-permanent] <item>...",
+	description: "delete items, moving them to the recycle bin unless -permanent is set",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		permanent := flags.Bool("permanent", false, "delete the items permanently instead of moving them to the recycle bin")
+
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) == 0 {
+				return errUsage
+			}
+
+			for _, arg := range args {
+				itemId, err := a.itemId(ctx, arg)
+				if err != nil {
+					return err
+				}
+
+				if *permanent {
+					err = a.client.DriveItems.PermanentDelete(ctx, a.driveId, itemId)
+				} else {
+					err = a.client.DriveItems.Delete(ctx, a.driveId, itemId)
+				}
+
+				if err != nil {
+					return fmt.Errorf("%s: %v", arg, err)
+				}
+			}
+
+			return nil
+		}
+	},
+}
+
+var mvCommand = &command{
+	usage:       "[-name new name] <item> [destination folder]",
+	description: "move an item to another folder, rename it, or both",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		newName := flags.String("name", "", "new name of the item")
+
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) == 0 || len(args) > 2 || (len(args) == 1 && *newName == "") {
+				return errUsage
+			}
+
+			itemId, err := a.itemId(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 2 {
+				folderId, err := a.itemId(ctx, args[1])
+				if err != nil {
+					return err
+				}
+
+				if _, err := a.client.DriveItems.Move(ctx, a.driveId, itemId, folderId); err != nil {
+					return err
+				}
+			}
+
+			if *newName != "" {
+				if _, err := a.client.DriveItems.Rename(ctx, a.driveId, itemId, *newName); err != nil {
+					return err
+				}
+			}
+
+			item, err := a.client.DriveItems.GetInDrive(ctx, a.driveId, itemId)
+			if err != nil {
+				return err
+			}
+
+			return a.printItem(item)
+		}
+	},
+}
+
+var cpCommand = &command{
+	usage:       "[-name new name] <item> <destination folder>",
+	description: "copy an item, along with its content, to a folder and wait for the copy to complete",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		newName := flags.String("name", "", "name of the copy; defaults to the name of the item")
+
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 2 {
+				return errUsage
+			}
+
+			source, err := a.item(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			folderId, err := a.itemId(ctx, args[1])
+			if err != nil {
+				return err
+			}
+
+			name := *newName
+			if name == "" {
+				name = source.Name
+			}
+
+			item, err := a.client.DriveItems.CopyAndWait(ctx, a.driveId, source.Id, a.driveId, folderId, name, nil)
+			if err != nil {
+				return err
+			}
+
+			return a.printItem(item)
+		}
+	},
+}
+
+var shareCommand = &command{
+	usage:       "[-type view|edit|embed|blocksDownload|createOnly|addressBar|adminDefault] [-scope anonymous|organization|users] [-expires duration] <item>",
+	description: "create a sharing link of an item, or return the existing link of the same type and scope",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		linkType := flags.String("type", "view", "type of the link: view, edit, embed, blocksDownload, createOnly, addressBar or adminDefault")
+		linkScope := flags.String("scope", "anonymous", "scope of the link: anonymous, organization or users")
+		password := flags.String("password", "", "password of the link (OneDrive personal only)")
+		expires := flags.Duration("expires", 0, "duration after which the link expires, e.g. 72h; the link does not expire by default")
+
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+
+			permissionType, ok := shareLinkTypes[*linkType]
+			if !ok {
+				return fmt.Errorf("unknown link type %q", *linkType)
+			}
+
+			permissionScope, ok := shareLinkScopes[*linkScope]
+			if !ok {
+				return fmt.Errorf("unknown link scope %q", *linkScope)
+			}
+
+			itemId, err := a.itemId(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			options := &onedrive.ShareLinkOptions{Password: *password}
+			if *expires > 0 {
+				options.ExpirationDateTime = time.Now().Add(*expires).UTC()
+			}
+
+			permission, err := a.client.DrivePermissions.CreateShareLinkWithOptions(ctx, a.driveId, itemId, permissionType, permissionScope, options)
+			if err != nil {
+				return err
+			}
+
+			if a.json {
+				return a.printJSON(permission)
+			}
+
+			_, err = fmt.Fprintln(a.stdout, permission.Link.URL)
+			return err
+		}
+	},
+}
+
+var searchCommand = &command{
+	usage:       "[-folder folder] <query>",
+	description: "search for items in the drive, or in a folder and its subfolders",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		folder := flags.String("folder", "", "folder to search in; defaults to the whole drive")
+
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+
+			options := &onedrive.SearchOptions{AllPages: true}
+
+			var response *onedrive.OneDriveDriveSearchResponse
+			if *folder == "" {
+				var err error
+				if response, err = a.client.DriveSearch.SearchInDrive(ctx, a.driveId, args[0], options); err != nil {
+					return err
+				}
+			} else {
+				folderId, err := a.itemId(ctx, *folder)
+				if err != nil {
+					return err
+				}
+
+				if response, err = a.client.DriveSearch.SearchInFolder(ctx, a.driveId, folderId, args[0], options); err != nil {
+					return err
+				}
+			}
+
+			return a.printItems(response.DriveItems)
+		}
+	},
+}
+
+var quotaCommand = &command{
+	usage:       "",
+	description: "show the quota of the drive",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+
+			drive, err := a.client.Drives.Get(ctx, a.driveId)
+			if err != nil {
+				return err
+			}
+
+			if drive.Quota == nil {
+				return errors.New("the quota of the drive is not available")
+			}
+
+			return a.printQuota(drive.Quota)
+		}
+	},
+}
+
+var deltaCommand = &command{
+	usage:       "[-link delta link] [folder]",
+	description: "list the changes of the items of a folder, by default the whole drive, followed by the delta link to the next changes",
+	setup: func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error {
+		deltaLink := flags.String("link", "", "delta link returned by a previous call, to list only the changes made since then")
+
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) > 1 {
+				return errUsage
+			}
+
+			folderId := ""
+			if len(args) == 1 && *deltaLink == "" {
+				var err error
+				if folderId, err = a.itemId(ctx, args[0]); err != nil {
+					return err
+				}
+			}
+
+			response, err := a.client.DriveItems.Delta(ctx, a.driveId, folderId, *deltaLink)
+			if err != nil {
+				return err
+			}
+
+			return a.printDelta(response)
+		}
+	},
+}
+
+// parseItemId returns the item ID of an argument which addresses an item by its ID, i.e. with the "id:" prefix.
+func parseItemId(arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "id:") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(arg, "id:"), true
+}
+
+// item returns the item addressed by the argument, either by its ID or by its path.
+func (a *app) item(ctx context.Context, arg string) (*onedrive.DriveItem, error) {
+	if itemId, ok := parseItemId(arg); ok {
+		return a.client.DriveItems.GetInDrive(ctx, a.driveId, itemId)
+	}
+
+	return a.client.DriveItems.GetByPath(ctx, a.driveId, arg)
+}
+
+// itemId returns the ID of the item addressed by the argument, either by its ID or by its path.
+func (a *app) itemId(ctx context.Context, arg string) (string, error) {
+	if itemId, ok := parseItemId(arg); ok {
+		return itemId, nil
+	}
+
+	item, err := a.client.DriveItems.GetByPath(ctx, a.driveId, arg)
+	if err != nil {
+		return "", err
+	}
+
+	return item.Id, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Command onedrive is a command-line tool to work with the files in OneDrive, so that they can be
+// managed from scripts without writing Go.
+//
+// Usage:
+//
+//	onedrive [flags] <command> [command flags] [arguments]
+//
+// The commands are:
+//
+//	ls      list the items of a folder
+//	stat    show the details of an item
+//	get     download a file
+//	put     upload a file
+//	mkdir   create a folder
+//	rm      delete an item
+//	mv      move or rename an item
+//	cp      copy an item
+//	share   create a sharing link of an item
+//	search  search for items
+//	quota   show the quota of the drive
+//	delta   list the changes of the items of a folder
+//
+// The items are addressed by their path relative to the root folder of the drive, e.g. "Documents/report.docx",
+// or by their ID with the "id:" prefix, e.g. "id:01BYE5RZ6QN3ZWBTUFOFD3GSPGOHDJD36K".
+//
+// The access token is read from, in order of precedence, the file given by -token-file or the environment
+// variable ONEDRIVE_TOKEN_FILE, the environment variable ONEDRIVE_ACCESS_TOKEN, or it is redeemed from the
+// refresh token in ONEDRIVE_REFRESH_TOKEN for the app registration given by ONEDRIVE_CLIENT_ID and, for
+// confidential clients, ONEDRIVE_CLIENT_SECRET. ONEDRIVE_TENANT defaults to "common".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// command is a subcommand of the tool.
+type command struct {
+	usage       string // Arguments of the command, following its name.
+	description string
+
+	// setup defines the flags of the command, if any, and returns the function running the command with
+	// the arguments which follow the flags.
+	setup func(flags *flag.FlagSet) func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = map[string]*command{
+	"ls":     lsCommand,
+	"stat":   statCommand,
+	"get":    getCommand,
+	"put":    putCommand,
+	"mkdir":  mkdirCommand,
+	"rm":     rmCommand,
+	"mv":     mvCommand,
+	"cp":     cpCommand,
+	"share":  shareCommand,
+	"search": searchCommand,
+	"quota":  quotaCommand,
+	"delta":  deltaCommand,
+}
+
+// app holds the settings shared by all the commands.
+type app struct {
+	client  *onedrive.Client
+	driveId string // Empty for the default drive of the authenticated user.
+	json    bool   // Whether the output is JSON instead of text.
+	stdout  io.Writer
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run runs the tool with the given arguments and environment variables, and returns the exit code.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer, getenv func(string) string) int {
+	globalFlags := flag.NewFlagSet("onedrive", flag.ContinueOnError)
+	globalFlags.SetOutput(stderr)
+	globalFlags.Usage = func() { printUsage(globalFlags) }
+
+	driveId := globalFlags.String("drive", "", "ID of the drive; defaults to the drive of the authenticated user")
+	user := globalFlags.String("user", "", "ID or user principal name of the user whose drive is accessed, when using application permissions")
+	jsonOutput := globalFlags.Bool("json", false, "print the output as JSON")
+	tokenFile := globalFlags.String("token-file", getenv("ONEDRIVE_TOKEN_FILE"), "file containing the access token, or an OAuth2 token in JSON")
+	baseURL := globalFlags.String("base-url", "", "base URL of the API; defaults to Microsoft Graph")
+
+	if err := globalFlags.Parse(args); err != nil {
+		return 2
+	}
+
+	if globalFlags.NArg() == 0 {
+		printUsage(globalFlags)
+		return 2
+	}
+
+	name := globalFlags.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "onedrive: unknown command %q\n", name)
+		printUsage(globalFlags)
+		return 2
+	}
+
+	cmdFlags := flag.NewFlagSet("onedrive "+name, flag.ContinueOnError)
+	cmdFlags.SetOutput(stderr)
+	cmdFlags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: onedrive %s %s\n\nThe command will %s.\n\n", name, cmd.usage, cmd.description)
+		cmdFlags.PrintDefaults()
+	}
+	runCommand := cmd.setup(cmdFlags)
+
+	if err := cmdFlags.Parse(globalFlags.Args()[1:]); err != nil {
+		return 2
+	}
+
+	httpClient, err := newHTTPClient(ctx, *tokenFile, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "onedrive: %v\n", err)
+		return 1
+	}
+
+	client := onedrive.NewClient(httpClient)
+	if *baseURL != "" {
+		if client.BaseURL, err = url.Parse(strings.TrimSuffix(*baseURL, "/") + "/"); err != nil {
+			fmt.Fprintf(stderr, "onedrive: invalid base URL: %v\n", err)
+			return 2
+		}
+
+		// The monitor URLs of the async jobs of a custom API, e.g. of a national cloud or of a test
+		// server, are hosted along with the API.
+		client.MonitorHosts = append(client.MonitorHosts, client.BaseURL.Scheme+"://"+client.BaseURL.Host)
+	}
+
+	if *user != "" {
+		client = client.ForUser(*user)
+	}
+
+	a := &app{client: client, driveId: *driveId, json: *jsonOutput, stdout: stdout}
+
+	if err := runCommand(ctx, a, cmdFlags.Args()); err != nil {
+		if err == errUsage {
+			cmdFlags.Usage()
+			return 2
+		}
+
+		fmt.Fprintf(stderr, "onedrive %s: %v\n", name, err)
+		return 1
+	}
+
+	return 0
+}
+
+// printUsage prints the usage of the tool, including the global flags and the list of the commands.
+func printUsage(globalFlags *flag.FlagSet) {
+	w := globalFlags.Output()
+
+	fmt.Fprintf(w, "Usage: onedrive [flags] <command> [command flags] [arguments]\n\nThe commands are:\n\n")
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-7s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintf(w, "\nItems are addressed by path, e.g. \"Documents/report.docx\", or by ID, e.g. \"id:01BYE5RZ6QN3Z\".\n\nFlags:\n")
+	globalFlags.PrintDefaults()
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+	"github.com/goh-chunlin/go-onedrive/onedrive/onedrivetest"
+)
+
+// runCommand runs the tool against the fake server and returns its standard output, failing the test if the
+// exit code is not the wanted one.
+func runCommand(t *testing.T, server *onedrivetest.Server, wantCode int, args ...string) string {
+	t.Helper()
+
+	env := map[string]string{"ONEDRIVE_ACCESS_TOKEN": "access-token"}
+	getenv := func(key string) string { return env[key] }
+
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-base-url", server.URL}, args...)
+
+	if code := run(context.Background(), args, &stdout, &stderr, getenv); code != wantCode {
+		t.Fatalf("onedrive %v exited with %d, want %d: %s", args, code, wantCode, stderr.String())
+	}
+
+	return stdout.String()
+}
+
+func TestRun_itemCommands(t *testing.T) {
+	server := onedrivetest.NewServer()
+	defer server.Close()
+
+	report := server.AddFile("Documents/report.txt", []byte("Hello"))
+
+	if output := runCommand(t, server, 0, "ls", "Documents"); !strings.Contains(output, report.Id) || !strings.Contains(output, "report.txt") {
+		t.Errorf("ls returned %q, want the report", output)
+	}
+
+	documents := server.ItemByPath("Documents")
+	if output := runCommand(t, server, 0, "ls", "id:"+documents.Id); !strings.Contains(output, "report.txt") {
+		t.Errorf("ls by ID returned %q, want the report", output)
+	}
+
+	var item onedrive.DriveItem
+	if err := json.Unmarshal([]byte(runCommand(t, server, 0, "-json", "stat", "Documents/report.txt")), &item); err != nil {
+		t.Fatalf("stat -json returned invalid JSON: %v", err)
+	}
+
+	if item.Id != report.Id || item.Size != 5 {
+		t.Errorf("stat -json returned %+v", item)
+	}
+
+	if output := runCommand(t, server, 0, "get", "id:"+report.Id, "-"); output != "Hello" {
+		t.Errorf("get returned %q, want %q", output, "Hello")
+	}
+
+	dir, err := ioutil.TempDir("", "onedrive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	localPath := filepath.Join(dir, "notes.txt")
+	if err := ioutil.WriteFile(localPath, []byte("Notes"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	runCommand(t, server, 0, "put", localPath, "Archive/2020")
+	if notes := server.ItemByPath("Archive/2020/notes.txt"); notes == nil || string(server.Content(notes.Id)) != "Notes" {
+		t.Fatalf("put did not upload the file, got %+v", notes)
+	}
+
+	runCommand(t, server, 0, "get", "Archive/2020/notes.txt", dir+string(filepath.Separator))
+	if content, err := ioutil.ReadFile(localPath); err != nil || string(content) != "Notes" {
+		t.Errorf("get wrote %q, %v", content, err)
+	}
+
+	runCommand(t, server, 0, "mkdir", "Documents/Drafts")
+	if drafts := server.ItemByPath("Documents/Drafts"); drafts == nil || drafts.Folder == nil {
+		t.Errorf("mkdir did not create the folder, got %+v", drafts)
+	}
+
+	runCommand(t, server, 0, "mv", "-name", "final.txt", "Documents/report.txt", "Documents/Drafts")
+	if moved := server.ItemByPath("Documents/Drafts/final.txt"); moved == nil || moved.Id != report.Id {
+		t.Errorf("mv did not move and rename the report, got %+v", moved)
+	}
+
+	runCommand(t, server, 0, "cp", "-name", "copy.txt", "Documents/Drafts/final.txt", "Archive")
+	if copied := server.ItemByPath("Archive/copy.txt"); copied == nil || string(server.Content(copied.Id)) != "Hello" {
+		t.Errorf("cp did not copy the report, got %+v", copied)
+	}
+
+	runCommand(t, server, 0, "cp", "id:"+report.Id, "Archive")
+	if copied := server.ItemByPath("Archive/final.txt"); copied == nil || string(server.Content(copied.Id)) != "Hello" {
+		t.Errorf("cp without -name did not copy the report by its name, got %+v", copied)
+	}
+
+	runCommand(t, server, 0, "rm", "Archive/copy.txt", "Archive/final.txt", "id:"+report.Id)
+	if server.ItemByPath("Archive/copy.txt") != nil || server.ItemByPath("Archive/final.txt") != nil || server.Item(report.Id) != nil {
+		t.Errorf("rm did not delete the items")
+	}
+
+	runCommand(t, server, 1, "stat", "Documents/missing.txt")
+	runCommand(t, server, 2, "mv", "Documents/Drafts")
+}
+
+func TestRun_driveCommands(t *testing.T) {
+	server := onedrivetest.NewServer()
+	defer server.Close()
+
+	report := server.AddFile("Documents/report.txt", []byte("Hello"))
+
+	if output := runCommand(t, server, 0, "share", "-type", "edit", "Documents/report.txt"); !strings.Contains(output, "/share/") {
+		t.Errorf("share returned %q, want a sharing link", output)
+	}
+
+	if permissions := server.Permissions(report.Id); len(permissions) != 1 || permissions[0].Link.Type != "edit" {
+		t.Errorf("share created %+v, want an edit link", permissions)
+	}
+
+	runCommand(t, server, 0, "share", "-type", "blocksDownload", "-scope", "users", "Documents/report.txt")
+	if permissions := server.Permissions(report.Id); len(permissions) != 2 || permissions[1].Link.Type != "blocksDownload" {
+		t.Errorf("share created %+v, want a blocksDownload link", permissions)
+	}
+
+	runCommand(t, server, 1, "share", "-type", "unknown", "Documents/report.txt")
+
+	if output := runCommand(t, server, 0, "search", "-folder", "Documents", "report"); !strings.Contains(output, report.Id) {
+		t.Errorf("search returned %q, want the report", output)
+	}
+
+	server.SetQuota(1000)
+	var quota onedrive.DriveQuota
+	if err := json.Unmarshal([]byte(runCommand(t, server, 0, "-json", "quota")), &quota); err != nil {
+		t.Fatalf("quota -json returned invalid JSON: %v", err)
+	}
+
+	if quota.Total != 1000 || quota.Used != 5 {
+		t.Errorf("quota -json returned %+v", quota)
+	}
+
+	var delta onedrive.OneDriveDeltaResponse
+	if err := json.Unmarshal([]byte(runCommand(t, server, 0, "-json", "delta", "Documents")), &delta); err != nil {
+		t.Fatalf("delta -json returned invalid JSON: %v", err)
+	}
+
+	if delta.DeltaLink == "" || len(delta.DriveItems) != 2 {
+		t.Fatalf("delta -json returned %+v, want the folder and the report", delta)
+	}
+
+	server.AddFile("Documents/notes.txt", []byte("Notes"))
+
+	output := runCommand(t, server, 0, "delta", "-link", delta.DeltaLink)
+	if !strings.Contains(output, "notes.txt") || strings.Contains(output, "report.txt") || !strings.Contains(output, "Delta link: ") {
+		t.Errorf("delta -link returned %q, want only the new notes", output)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// timeLayout is the layout of the times in the text output.
+const timeLayout = "2006-01-02 15:04:05"
+
+// printJSON prints the value as indented JSON.
+func (a *app) printJSON(value interface{}) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+// printItems prints the items, one per line with their ID, size, last modified time and name, or as a JSON array.
+func (a *app) printItems(items []*onedrive.DriveItem) error {
+	if a.json {
+		if items == nil {
+			items = []*onedrive.DriveItem{}
+		}
+
+		return a.printJSON(items)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Id, itemSize(item), formatTime(item.LastModifiedDateTime), itemName(item))
+	}
+
+	return w.Flush()
+}
+
+// printItem prints the details of the item, one property per line, or as a JSON object.
+func (a *app) printItem(item *onedrive.DriveItem) error {
+	if a.json {
+		return a.printJSON(item)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", item.Name)
+	fmt.Fprintf(w, "ID:\t%s\n", item.Id)
+
+	switch {
+	case item.Folder != nil:
+		fmt.Fprintf(w, "Type:\tfolder\n")
+	case item.File != nil:
+		fmt.Fprintf(w, "Type:\tfile\n")
+		if item.File.MIMEType != "" {
+			fmt.Fprintf(w, "MIME type:\t%s\n", item.File.MIMEType)
+		}
+	}
+
+	fmt.Fprintf(w, "Size:\t%d\n", item.Size)
+	fmt.Fprintf(w, "Created:\t%s\n", formatTime(item.CreatedDateTime))
+	fmt.Fprintf(w, "Modified:\t%s\n", formatTime(item.LastModifiedDateTime))
+
+	if item.ParentReference != nil && item.ParentReference.Path != "" {
+		fmt.Fprintf(w, "Parent:\t%s\n", item.ParentReference.Path)
+	}
+
+	if item.WebURL != "" {
+		fmt.Fprintf(w, "Web URL:\t%s\n", item.WebURL)
+	}
+
+	return w.Flush()
+}
+
+// printQuota prints the quota of a drive in bytes, one property per line, or as a JSON object.
+func (a *app) printQuota(quota *onedrive.DriveQuota) error {
+	if a.json {
+		return a.printJSON(quota)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Total:\t%d\n", quota.Total)
+	fmt.Fprintf(w, "Used:\t%d\n", quota.Used)
+	fmt.Fprintf(w, "Deleted:\t%d\n", quota.Deleted)
+	fmt.Fprintf(w, "Remaining:\t%d\n", quota.Remaining)
+	fmt.Fprintf(w, "State:\t%s\n", quota.State)
+
+	return w.Flush()
+}
+
+// printDelta prints the changed items, with "deleted" instead of the size of the deleted items, followed by the
+// delta link, or the delta response as a JSON object.
+func (a *app) printDelta(response *onedrive.OneDriveDeltaResponse) error {
+	if a.json {
+		if response.DriveItems == nil {
+			response.DriveItems = []*onedrive.DriveItem{}
+		}
+
+		return a.printJSON(response)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	for _, item := range response.DriveItems {
+		size := itemSize(item)
+		if item.Deleted != nil {
+			size = "deleted"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Id, size, formatTime(item.LastModifiedDateTime), itemName(item))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(a.stdout, "Delta link: %s\n", response.DeltaLink)
+	return err
+}
+
+// itemSize returns the size of a file, or "-" for the other items.
+func itemSize(item *onedrive.DriveItem) string {
+	if item.File == nil {
+		return "-"
+	}
+
+	return strconv.FormatInt(item.Size, 10)
+}
+
+// itemName returns the name of an item, followed by a slash for the folders.
+func itemName(item *onedrive.DriveItem) string {
+	if item.Folder != nil {
+		return item.Name + "/"
+	}
+
+	return item.Name
+}
+
+// formatTime formats a time in the local time zone, or returns "-" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Local().Format(timeLayout)
+}
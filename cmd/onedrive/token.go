@@ -0,0 +1,114 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+// defaultScopes are the scopes requested when an access token is redeemed from a refresh token.
+var defaultScopes = []string{"Files.ReadWrite.All", "offline_access"}
+
+// newHTTPClient returns an HTTP client which authenticates the requests with the access token from the
+// token file, the environment variable ONEDRIVE_ACCESS_TOKEN or the refresh token in ONEDRIVE_REFRESH_TOKEN.
+func newHTTPClient(ctx context.Context, tokenFile string, getenv func(string) string) (*http.Client, error) {
+	var token *oauth2.Token
+
+	switch {
+	case tokenFile != "":
+		var err error
+		if token, err = readTokenFile(tokenFile); err != nil {
+			return nil, err
+		}
+	case getenv("ONEDRIVE_ACCESS_TOKEN") != "":
+		token = &oauth2.Token{AccessToken: getenv("ONEDRIVE_ACCESS_TOKEN")}
+	case getenv("ONEDRIVE_REFRESH_TOKEN") != "":
+		token = &oauth2.Token{RefreshToken: getenv("ONEDRIVE_REFRESH_TOKEN")}
+	default:
+		return nil, errors.New("no access token: set -token-file, ONEDRIVE_TOKEN_FILE, ONEDRIVE_ACCESS_TOKEN or ONEDRIVE_REFRESH_TOKEN")
+	}
+
+	if token.RefreshToken == "" {
+		return oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)), nil
+	}
+
+	clientId := getenv("ONEDRIVE_CLIENT_ID")
+	if clientId == "" {
+		if token.AccessToken == "" {
+			return nil, errors.New("ONEDRIVE_CLIENT_ID must be set to redeem the refresh token")
+		}
+
+		// Without the app registration, the access token is used as it is until it expires.
+		return oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)), nil
+	}
+
+	tenant := getenv("ONEDRIVE_TENANT")
+	if tenant == "" {
+		tenant = "common"
+	}
+
+	config := &oauth2.Config{
+		ClientID:     clientId,
+		ClientSecret: getenv("ONEDRIVE_CLIENT_SECRET"),
+		Endpoint:     microsoft.AzureADEndpoint(tenant),
+		Scopes:       defaultScopes,
+	}
+
+	return oauth2.NewClient(ctx, config.TokenSource(ctx, token)), nil
+}
+
+// readTokenFile reads the token from a file, which contains either an OAuth2 token in JSON, e.g. as saved
+// by golang.org/x/oauth2 or returned by the token endpoint, or the access token alone.
+func readTokenFile(tokenFile string) (*oauth2.Token, error) {
+	content, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	content = []byte(strings.TrimSpace(string(content)))
+	if len(content) == 0 {
+		return nil, errors.New("the token file " + tokenFile + " is empty")
+	}
+
+	if content[0] != '{' {
+		return &oauth2.Token{AccessToken: string(content)}, nil
+	}
+
+	var token struct {
+		oauth2.Token
+		ExpiresIn int64 `json:"expires_in"`
+	}
+	if err := json.Unmarshal(content, &token); err != nil {
+		return nil, errors.New("the token file " + tokenFile + " is not valid JSON: " + err.Error())
+	}
+
+	if token.AccessToken == "" && token.RefreshToken == "" {
+		return nil, errors.New("the token file " + tokenFile + " has neither an access token nor a refresh token")
+	}
+
+	// A token returned by the token endpoint has the lifetime of the access token instead of its expiry.
+	// Without the expiry, the access token would be used as it is even after it has expired. The lifetime
+	// counts from when the token was saved, i.e. the last modification of the file.
+	if token.Expiry.IsZero() && token.ExpiresIn > 0 {
+		fileInfo, err := os.Stat(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+
+		token.Expiry = fileInfo.ModTime().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return &token.Token, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadTokenFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onedrive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		content          string
+		wantAccessToken  string
+		wantRefreshToken string
+		wantError        bool
+	}{
+		{content: "access-token\n", wantAccessToken: "access-token"},
+		{content: `{"access_token": "access-token", "token_type": "Bearer", "refresh_token": "refresh-token", "expires_in": 3600}`, wantAccessToken: "access-token", wantRefreshToken: "refresh-token"},
+		{content: `{"refresh_token": "refresh-token"}`, wantRefreshToken: "refresh-token"},
+		{content: `{"token_type": "Bearer"}`, wantError: true},
+		{content: `{"access_token": `, wantError: true},
+		{content: " \n", wantError: true},
+	}
+
+	for i, test := range tests {
+		tokenFile := filepath.Join(dir, "token")
+		if err := ioutil.WriteFile(tokenFile, []byte(test.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := readTokenFile(tokenFile)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("Test %d: readTokenFile returned %+v, want an error", i, token)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Test %d: readTokenFile returned error: %v", i, err)
+			continue
+		}
+
+		if token.AccessToken != test.wantAccessToken || token.RefreshToken != test.wantRefreshToken {
+			t.Errorf("Test %d: readTokenFile returned %+v", i, token)
+		}
+	}
+}
+
+func TestReadTokenFile_expiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onedrive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	content := `{"access_token": "access-token", "refresh_token": "refresh-token", "expires_in": 3600}`
+	if err := ioutil.WriteFile(tokenFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The token was saved two hours ago, hence its access token has expired an hour ago.
+	savedAt := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(tokenFile, savedAt, savedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := readTokenFile(tokenFile)
+	if err != nil {
+		t.Fatalf("readTokenFile returned error: %v", err)
+	}
+
+	if want := savedAt.Add(time.Hour); !token.Expiry.Equal(want) {
+		t.Errorf("readTokenFile returned expiry %v, want %v", token.Expiry, want)
+	}
+
+	if token.Valid() {
+		t.Errorf("readTokenFile returned a valid token, want the access token of the old file expired")
+	}
+
+	content = `{"access_token": "access-token", "refresh_token": "refresh-token", "expiry": "2020-11-08T10:00:00Z", "expires_in": 3600}`
+	if err := ioutil.WriteFile(tokenFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err = readTokenFile(tokenFile)
+	if err != nil {
+		t.Fatalf("readTokenFile returned error: %v", err)
+	}
+
+	if want := time.Date(2020, 11, 8, 10, 0, 0, 0, time.UTC); !token.Expiry.Equal(want) {
+		t.Errorf("readTokenFile returned expiry %v, want %v", token.Expiry, want)
+	}
+}
+
+func TestNewHTTPClient_tokenSources(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		env       map[string]string
+		wantError bool
+	}{
+		{env: map[string]string{}, wantError: true},
+		{env: map[string]string{"ONEDRIVE_ACCESS_TOKEN": "access-token"}},
+		{env: map[string]string{"ONEDRIVE_REFRESH_TOKEN": "refresh-token"}, wantError: true},
+		{env: map[string]string{"ONEDRIVE_REFRESH_TOKEN": "refresh-token", "ONEDRIVE_CLIENT_ID": "client-id"}},
+	}
+
+	for i, test := range tests {
+		env := test.env
+		_, err := newHTTPClient(ctx, "", func(key string) string { return env[key] })
+		if (err != nil) != test.wantError {
+			t.Errorf("Test %d: newHTTPClient returned error %v, want error: %v", i, err, test.wantError)
+		}
+	}
+}
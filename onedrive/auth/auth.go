@@ -0,0 +1,321 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package auth acquires the access tokens of the OneDrive API from the Microsoft identity platform.
+//
+// The flows of a Config return an oauth2.TokenSource, which refreshes the access token when it expires,
+// to authenticate the HTTP client of onedrive.NewClient:
+//
+//	config := &auth.Config{ClientId: "...", Store: auth.NewFileStore("token.json")}
+//
+//	ts, err := config.StoredToken(ctx)
+//	if err == auth.ErrTokenNotFound {
+//		ts, err = config.DeviceCode(ctx, func(code *auth.DeviceCodeResponse) error {
+//			fmt.Println(code.Message)
+//			return nil
+//		})
+//	}
+//	if err != nil {
+//		return err
+//	}
+//
+//	client := onedrive.NewClient(oauth2.NewClient(ctx, ts))
+//
+// The flows on behalf of a user, i.e. the device code, the authorization code and the refresh token flows,
+// save the tokens to the Store of the Config whenever they change, so that the user does not have to sign in
+// again the next time.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultAuthorityURL is the URL of the Microsoft identity platform in the global cloud.
+	DefaultAuthorityURL = "https://login.microsoftonline.com/"
+
+	// DefaultTenant allows both the work or school accounts and the personal Microsoft accounts to sign in.
+	DefaultTenant = "common"
+
+	// AppScope is the scope of the client credentials flow, which grants the application permissions
+	// consented for the app registration.
+	AppScope = "https://graph.microsoft.com/.default"
+)
+
+// DefaultScopes are the scopes requested on behalf of a user when the Config does not specify any. The
+// offline_access scope is required to receive a refresh token.
+var DefaultScopes = []string{"Files.ReadWrite.All", "offline_access"}
+
+// ErrTokenNotFound is returned by the TokenStore and by Config.StoredToken when no token has been saved yet.
+var ErrTokenNotFound = errors.New("The token has not been saved yet.")
+
+// Config represents the app registration and the settings used to acquire the tokens.
+type Config struct {
+	// ClientId is the application (client) ID of the app registration.
+	ClientId string
+
+	// ClientSecret is the secret of a confidential client. It must be empty for public clients, e.g. desktop
+	// and command-line apps, which cannot keep a secret.
+	ClientSecret string
+
+	// Certificate authenticates a confidential client in the client credentials flow instead of ClientSecret.
+	Certificate *ClientCertificate
+
+	// Tenant is the directory (tenant) ID or domain to sign in to, or one of "common", "organizations" and
+	// "consumers". Defaults to DefaultTenant. The client credentials flow requires a specific tenant.
+	Tenant string
+
+	// Scopes are the permissions requested on behalf of a user. Defaults to DefaultScopes.
+	Scopes []string
+
+	// AuthorityURL is the URL of the Microsoft identity platform, with a trailing slash, which the tenant
+	// is appended to. Defaults to DefaultAuthorityURL. It can be set to the URL of a national cloud, or to
+	// a local fake token endpoint in tests.
+	AuthorityURL string
+
+	// HTTPClient sends the requests to the Microsoft identity platform. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Store saves the tokens acquired on behalf of a user, if set.
+	Store TokenStore
+}
+
+// Error represents an error returned by the Microsoft identity platform.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/reference-aadsts-error-codes
+type Error struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + " - " + e.Description
+}
+
+// tokenResponse represents the JSON object returned by the token endpoint.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// endpointURL returns the URL of an OAuth 2.0 endpoint of the tenant, e.g. "token" or "devicecode".
+func (c *Config) endpointURL(endpoint string) string {
+	authorityURL := c.AuthorityURL
+	if authorityURL == "" {
+		authorityURL = DefaultAuthorityURL
+	}
+
+	tenant := c.Tenant
+	if tenant == "" {
+		tenant = DefaultTenant
+	}
+
+	return strings.TrimSuffix(authorityURL, "/") + "/" + url.PathEscape(tenant) + "/oauth2/v2.0/" + endpoint
+}
+
+// scopes returns the scopes requested on behalf of a user.
+func (c *Config) scopes() string {
+	if len(c.Scopes) == 0 {
+		return strings.Join(DefaultScopes, " ")
+	}
+
+	return strings.Join(c.Scopes, " ")
+}
+
+// post sends a form to an endpoint and decodes the JSON response into target, or returns an *Error if the
+// Microsoft identity platform responds with an error.
+func (c *Config) post(ctx context.Context, endpoint string, form url.Values, target interface{}) error {
+	req, err := http.NewRequest("POST", c.endpointURL(endpoint), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		authError := &Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(body, authError); err != nil || authError.Code == "" {
+			authError.Code = "unexpected_response"
+			authError.Description = resp.Status + ": " + string(body)
+		}
+
+		return authError
+	}
+
+	return json.Unmarshal(body, target)
+}
+
+// requestToken sends a token request with the given grant and returns the token. The client ID, and the
+// client secret of confidential clients, are added to the form.
+func (c *Config) requestToken(ctx context.Context, form url.Values) (*oauth2.Token, error) {
+	form.Set("client_id", c.ClientId)
+	if c.ClientSecret != "" && form.Get("client_assertion") == "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+
+	var response tokenResponse
+	if err := c.post(ctx, "token", form, &response); err != nil {
+		return nil, err
+	}
+
+	if response.AccessToken == "" {
+		return nil, errors.New("The token endpoint did not return an access token.")
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  response.AccessToken,
+		TokenType:    response.TokenType,
+		RefreshToken: response.RefreshToken,
+	}
+	if response.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// RefreshToken returns a token source which redeems the given refresh token for access tokens, e.g. a
+// refresh token which has been acquired by another app or saved elsewhere.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow#refresh-the-access-token
+func (c *Config) RefreshToken(ctx context.Context, refreshToken string) oauth2.TokenSource {
+	return c.userTokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
+}
+
+// StoredToken returns a token source which starts from the token saved in the Store, so that the user does
+// not have to sign in again. ErrTokenNotFound is returned if no token has been saved yet.
+func (c *Config) StoredToken(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.Store == nil {
+		return nil, errors.New("Please provide the store of the tokens.")
+	}
+
+	token, err := c.Store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" && !token.Valid() {
+		return nil, ErrTokenNotFound
+	}
+
+	return c.userTokenSource(ctx, token), nil
+}
+
+// newUserTokenSource saves a token newly acquired on behalf of a user to the Store, if any, and returns
+// the token source starting from the token.
+func (c *Config) newUserTokenSource(ctx context.Context, token *oauth2.Token) (oauth2.TokenSource, error) {
+	if c.Store != nil {
+		if err := c.Store.Save(token); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.userTokenSource(ctx, token), nil
+}
+
+// userTokenSource returns a token source which starts from the given token acquired on behalf of a user,
+// refreshes it when it expires and saves the new tokens to the Store, if any.
+func (c *Config) userTokenSource(ctx context.Context, token *oauth2.Token) oauth2.TokenSource {
+	var ts oauth2.TokenSource = oauth2.ReuseTokenSource(token, &refreshTokenSource{ctx: ctx, config: c, refreshToken: token.RefreshToken})
+	if c.Store != nil {
+		ts = &storingTokenSource{base: ts, store: c.Store, last: token}
+	}
+
+	return ts
+}
+
+// refreshTokenSource acquires a new token with the refresh token each time it is called. Microsoft identity
+// platform may return a new refresh token along with the access token, which replaces the previous one.
+type refreshTokenSource struct {
+	ctx    context.Context
+	config *Config
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+func (s *refreshTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refreshToken == "" {
+		return nil, errors.New("The token has expired and there is no refresh token to renew it.")
+	}
+
+	token, err := s.config.requestToken(s.ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.refreshToken},
+		"scope":         {s.config.scopes()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = s.refreshToken
+	}
+	s.refreshToken = token.RefreshToken
+
+	return token, nil
+}
+
+// storingTokenSource saves the tokens of the base token source to the store whenever they change.
+type storingTokenSource struct {
+	base  oauth2.TokenSource
+	store TokenStore
+
+	mu   sync.Mutex
+	last *oauth2.Token
+}
+
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last == nil || s.last.AccessToken != token.AccessToken || s.last.RefreshToken != token.RefreshToken {
+		if err := s.store.Save(token); err != nil {
+			return nil, err
+		}
+
+		s.last = token
+	}
+
+	return token, nil
+}
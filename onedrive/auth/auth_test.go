@@ -0,0 +1,398 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	testClientId     = "11111111-2222-3333-4444-555555555555"
+	testClientSecret = "client-secret"
+	testTenant       = "contoso.onmicrosoft.com"
+)
+
+// fakeIdentityPlatform is a fake of the OAuth 2.0 endpoints of the Microsoft identity platform.
+type fakeIdentityPlatform struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu               sync.Mutex
+	expiresIn        int    // Lifetime of the access tokens, in seconds.
+	pendingPolls     int    // Number of polls of the device code answered with authorization_pending.
+	deviceCodeError  string // Error returned to the polls of the device code once they are no longer pending.
+	lastIssued       int
+	refreshToken     string // The only refresh token which is currently valid.
+	codeChallenge    string
+	redirectURI      string
+	certificateKey   *rsa.PublicKey
+	grantTypes       []string
+	lastTokenRequest url.Values
+}
+
+func newFakeIdentityPlatform(t *testing.T) *fakeIdentityPlatform {
+	f := &fakeIdentityPlatform{t: t, expiresIn: 3600}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+
+	return f
+}
+
+func (f *fakeIdentityPlatform) config() *Config {
+	return &Config{ClientId: testClientId, Tenant: testTenant, AuthorityURL: f.server.URL + "/"}
+}
+
+func (f *fakeIdentityPlatform) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prefix := "/" + testTenant + "/oauth2/v2.0/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeAuthError(w, http.StatusNotFound, "invalid_tenant", "The tenant could not be found.")
+		return
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, prefix) {
+	case "devicecode":
+		if r.FormValue("client_id") != testClientId {
+			writeAuthError(w, http.StatusBadRequest, "unauthorized_client", "The client does not exist.")
+			return
+		}
+
+		writeAuthJSON(w, map[string]interface{}{
+			"device_code":      "device-code",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": "https://microsoft.com/devicelogin",
+			"expires_in":       900,
+			"message":          "To sign in, enter the code ABCD-EFGH at https://microsoft.com/devicelogin.",
+		})
+	case "authorize":
+		query := r.URL.Query()
+		if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+			writeAuthError(w, http.StatusBadRequest, "invalid_request", "The code challenge is missing.")
+			return
+		}
+
+		f.codeChallenge = query.Get("code_challenge")
+		f.redirectURI = query.Get("redirect_uri")
+
+		redirect := url.Values{"code": {"authorization-code"}, "state": {query.Get("state")}}
+		http.Redirect(w, r, f.redirectURI+"?"+redirect.Encode(), http.StatusFound)
+	case "token":
+		f.token(w, r)
+	default:
+		writeAuthError(w, http.StatusNotFound, "invalid_request", "The endpoint could not be found.")
+	}
+}
+
+func (f *fakeIdentityPlatform) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") != testClientId {
+		writeAuthError(w, http.StatusBadRequest, "unauthorized_client", "The client does not exist.")
+		return
+	}
+
+	form := r.PostForm
+	f.grantTypes = append(f.grantTypes, form.Get("grant_type"))
+	f.lastTokenRequest = form
+
+	switch form.Get("grant_type") {
+	case deviceCodeGrantType:
+		if form.Get("device_code") != "device-code" {
+			writeAuthError(w, http.StatusBadRequest, "bad_verification_code", "The device code is not valid.")
+			return
+		}
+
+		if f.pendingPolls > 0 {
+			f.pendingPolls--
+			writeAuthError(w, http.StatusBadRequest, "authorization_pending", "The user has not signed in yet.")
+			return
+		}
+
+		if f.deviceCodeError != "" {
+			writeAuthError(w, http.StatusBadRequest, f.deviceCodeError, "The user has not signed in.")
+			return
+		}
+	case "authorization_code":
+		challenge := sha256.Sum256([]byte(form.Get("code_verifier")))
+		if form.Get("code") != "authorization-code" || base64.RawURLEncoding.EncodeToString(challenge[:]) != f.codeChallenge {
+			writeAuthError(w, http.StatusBadRequest, "invalid_grant", "The code verifier does not match the code challenge.")
+			return
+		}
+
+		if form.Get("redirect_uri") != f.redirectURI {
+			writeAuthError(w, http.StatusBadRequest, "invalid_grant", "The redirect URI does not match.")
+			return
+		}
+	case "refresh_token":
+		if form.Get("refresh_token") != f.refreshToken {
+			writeAuthError(w, http.StatusBadRequest, "invalid_grant", "The refresh token has expired or been revoked.")
+			return
+		}
+	case "client_credentials":
+		if form.Get("scope") != AppScope {
+			writeAuthError(w, http.StatusBadRequest, "invalid_scope", "The scope must be the default scope.")
+			return
+		}
+
+		if err := f.verifyClient(form); err != nil {
+			writeAuthError(w, http.StatusUnauthorized, "invalid_client", err.Error())
+			return
+		}
+
+		f.lastIssued++
+		writeAuthJSON(w, map[string]interface{}{
+			"access_token": "app-access-" + strconv.Itoa(f.lastIssued),
+			"token_type":   "Bearer",
+			"expires_in":   f.expiresIn,
+		})
+		return
+	default:
+		writeAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "The grant type is not supported.")
+		return
+	}
+
+	f.lastIssued++
+	f.refreshToken = "refresh-" + strconv.Itoa(f.lastIssued)
+
+	writeAuthJSON(w, map[string]interface{}{
+		"access_token":  "access-" + strconv.Itoa(f.lastIssued),
+		"token_type":    "Bearer",
+		"refresh_token": f.refreshToken,
+		"expires_in":    f.expiresIn,
+	})
+}
+
+// verifyClient verifies the client secret, or the client assertion signed with the key of the certificate.
+func (f *fakeIdentityPlatform) verifyClient(form url.Values) error {
+	if f.certificateKey == nil {
+		if form.Get("client_secret") != testClientSecret {
+			return errors.New("The client secret is not valid.")
+		}
+
+		return nil
+	}
+
+	if form.Get("client_assertion_type") != clientAssertionType {
+		return errors.New("The client assertion is missing.")
+	}
+
+	parts := strings.Split(form.Get("client_assertion"), ".")
+	if len(parts) != 3 {
+		return errors.New("The client assertion is not a JWT.")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(f.certificateKey, crypto.SHA256, hash[:], signature); err != nil {
+		return errors.New("The signature of the client assertion is not valid.")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return err
+	}
+
+	if claims["aud"] != f.server.URL+"/"+testTenant+"/oauth2/v2.0/token" || claims["iss"] != testClientId || claims["sub"] != testClientId {
+		return fmt.Errorf("The claims of the client assertion are not valid: %v", claims)
+	}
+
+	return nil
+}
+
+func writeAuthJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeAuthError(w http.ResponseWriter, statusCode int, code string, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": description})
+}
+
+// tempDir creates a temporary folder and returns its path along with a function removing it.
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestConfig_RefreshToken_rotatesAndSaves(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	// The access tokens are considered expired right away, so that each call refreshes the token.
+	f.expiresIn = 1
+	f.refreshToken = "refresh-0"
+
+	config := f.config()
+	config.Store = NewFileStore(filepath.Join(dir, "token.json"))
+
+	ts := config.RefreshToken(context.Background(), "refresh-0")
+
+	for i := 1; i <= 2; i++ {
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token returned error: %v", err)
+		}
+
+		if want := "access-" + strconv.Itoa(i); token.AccessToken != want {
+			t.Errorf("Token returned access token %q, want %q", token.AccessToken, want)
+		}
+
+		saved, err := config.Store.Load()
+		if err != nil {
+			t.Fatalf("Store.Load returned error: %v", err)
+		}
+
+		if want := "refresh-" + strconv.Itoa(i); saved.RefreshToken != want || saved.AccessToken != token.AccessToken {
+			t.Errorf("Saved token = %+v, want refresh token %q", saved, want)
+		}
+	}
+
+	if scope := f.lastTokenRequest.Get("scope"); scope != "Files.ReadWrite.All offline_access" {
+		t.Errorf("Refresh requested scope %q, want the default scopes", scope)
+	}
+}
+
+func TestConfig_RefreshToken_invalidGrant(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	f.refreshToken = "refresh-0"
+
+	_, err := f.config().RefreshToken(context.Background(), "revoked").Token()
+
+	var authError *Error
+	if !errors.As(err, &authError) || authError.Code != "invalid_grant" || authError.StatusCode != http.StatusBadRequest {
+		t.Errorf("Token returned error %v, want invalid_grant", err)
+	}
+}
+
+func TestConfig_StoredToken(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	config := f.config()
+	config.Store = NewFileStore(filepath.Join(dir, "token.json"))
+
+	if _, err := config.StoredToken(context.Background()); err != ErrTokenNotFound {
+		t.Fatalf("StoredToken returned error %v, want ErrTokenNotFound", err)
+	}
+
+	f.refreshToken = "refresh-0"
+	if err := config.Store.Save(&oauth2.Token{AccessToken: "expired", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := config.StoredToken(context.Background())
+	if err != nil {
+		t.Fatalf("StoredToken returned error: %v", err)
+	}
+
+	token, err := ts.Token()
+	if err != nil || token.AccessToken != "access-1" {
+		t.Errorf("Token returned %+v, %v, want the refreshed token", token, err)
+	}
+}
+
+func TestConfig_DeviceCode(t *testing.T) {
+	defer func(interval time.Duration) { defaultPollInterval = interval }(defaultPollInterval)
+	defaultPollInterval = 10 * time.Millisecond
+
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	f.pendingPolls = 2
+
+	config := f.config()
+	config.Store = NewFileStore(filepath.Join(dir, "token.json"))
+
+	var prompted *DeviceCodeResponse
+	ts, err := config.DeviceCode(context.Background(), func(code *DeviceCodeResponse) error {
+		prompted = code
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DeviceCode returned error: %v", err)
+	}
+
+	if prompted == nil || prompted.UserCode != "ABCD-EFGH" || !strings.Contains(prompted.Message, "ABCD-EFGH") {
+		t.Errorf("DeviceCode prompted %+v", prompted)
+	}
+
+	if want := []string{deviceCodeGrantType, deviceCodeGrantType, deviceCodeGrantType}; strings.Join(f.grantTypes, ",") != strings.Join(want, ",") {
+		t.Errorf("Token requests = %v, want %v", f.grantTypes, want)
+	}
+
+	token, err := ts.Token()
+	if err != nil || token.AccessToken != "access-1" {
+		t.Errorf("Token returned %+v, %v", token, err)
+	}
+
+	if saved, err := config.Store.Load(); err != nil || saved.RefreshToken != "refresh-1" {
+		t.Errorf("Saved token = %+v, %v, want the token of the device code", saved, err)
+	}
+}
+
+func TestConfig_DeviceCode_declined(t *testing.T) {
+	defer func(interval time.Duration) { defaultPollInterval = interval }(defaultPollInterval)
+	defaultPollInterval = 10 * time.Millisecond
+
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	f.pendingPolls = 1
+	f.deviceCodeError = "authorization_declined"
+
+	_, err := f.config().DeviceCode(context.Background(), func(code *DeviceCodeResponse) error { return nil })
+
+	var authError *Error
+	if !errors.As(err, &authError) || authError.Code != "authorization_declined" {
+		t.Errorf("DeviceCode returned error %v, want authorization_declined", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/oauth2"
+)
+
+// AuthCodeOptions represents the optional settings of the authorization code flow.
+type AuthCodeOptions struct {
+	// Port is the port of the loopback redirect listener. Defaults to a free port chosen by the system, which
+	// works with the redirect URI "http://localhost" of public clients, whose port is ignored by the Microsoft
+	// identity platform.
+	Port int
+
+	// Prompt is the prompt parameter of the sign-in, e.g. "select_account" or "consent". Empty by default.
+	Prompt string
+
+	// LoginHint is the user name to be filled in on the sign-in page, if any.
+	LoginHint string
+}
+
+// authCodeResult is the result of the redirect to the loopback listener.
+type authCodeResult struct {
+	code string
+	err  error
+}
+
+// AuthCode signs in a user with the authorization code flow and the Proof Key for Code Exchange (PKCE),
+// which suits the apps running on the device of the user, e.g. desktop apps and command-line tools.
+//
+// A listener on a loopback port receives the redirect of the sign-in. The openBrowser function is called
+// with the URL of the sign-in page, which it should open in the browser of the user, and the authorization
+// code is then waited for until the user has signed in, the sign-in has failed or ctx is done.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow
+func (c *Config) AuthCode(ctx context.Context, openBrowser func(authURL string) error, options *AuthCodeOptions) (oauth2.TokenSource, error) {
+	if openBrowser == nil {
+		return nil, errors.New("Please provide the function opening the sign-in page in the browser.")
+	}
+
+	if options == nil {
+		options = &AuthCodeOptions{}
+	}
+
+	listener, err := net.Listen("tcp", "localhost:"+strconv.Itoa(options.Port))
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+
+	redirectURI := fmt.Sprintf("http://localhost:%d/", listener.Addr().(*net.TCPAddr).Port)
+
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan authCodeResult, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// The requests which do not come from the redirect of this sign-in are ignored.
+		if r.URL.Query().Get("state") != state {
+			http.Error(w, "The state of the request does not match the state of the sign-in.", http.StatusBadRequest)
+			return
+		}
+
+		result := redirectResult(r.URL.Query())
+		if result.err != nil {
+			http.Error(w, "Sign-in failed: "+result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprint(w, "Sign-in completed. You can close this window now.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	query := url.Values{
+		"client_id":             {c.ClientId},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirectURI},
+		"response_mode":         {"query"},
+		"scope":                 {c.scopes()},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if options.Prompt != "" {
+		query.Set("prompt", options.Prompt)
+	}
+	if options.LoginHint != "" {
+		query.Set("login_hint", options.LoginHint)
+	}
+
+	if err := openBrowser(c.endpointURL("authorize") + "?" + query.Encode()); err != nil {
+		return nil, err
+	}
+
+	var result authCodeResult
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result = <-results:
+	}
+
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	token, err := c.requestToken(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+		"scope":         {c.scopes()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newUserTokenSource(ctx, token)
+}
+
+// redirectResult returns the authorization code, or the error, of the query of the redirect of the sign-in.
+func redirectResult(query url.Values) authCodeResult {
+	if code := query.Get("error"); code != "" {
+		return authCodeResult{err: &Error{Code: code, Description: query.Get("error_description")}}
+	}
+
+	if query.Get("code") == "" {
+		return authCodeResult{err: errors.New("The redirect of the sign-in does not have an authorization code.")}
+	}
+
+	return authCodeResult{code: query.Get("code")}
+}
+
+// randomString returns a URL-safe random string encoding the given number of random bytes.
+func randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_AuthCode(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	var authURL *url.URL
+	ts, err := f.config().AuthCode(context.Background(), func(rawURL string) error {
+		var err error
+		if authURL, err = url.Parse(rawURL); err != nil {
+			return err
+		}
+
+		// The browser follows the redirect of the sign-in page to the loopback listener.
+		resp, err := http.Get(rawURL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "Sign-in completed") {
+			t.Errorf("Redirect returned %d: %s", resp.StatusCode, body)
+		}
+
+		return nil
+	}, &AuthCodeOptions{Prompt: "select_account", LoginHint: "adele@contoso.com"})
+	if err != nil {
+		t.Fatalf("AuthCode returned error: %v", err)
+	}
+
+	query := authURL.Query()
+	if query.Get("client_id") != testClientId || query.Get("prompt") != "select_account" || query.Get("login_hint") != "adele@contoso.com" {
+		t.Errorf("Sign-in URL has query %v", query)
+	}
+
+	if !strings.HasPrefix(query.Get("redirect_uri"), "http://localhost:") {
+		t.Errorf("Sign-in URL has redirect URI %q, want a loopback URI", query.Get("redirect_uri"))
+	}
+
+	token, err := ts.Token()
+	if err != nil || token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" {
+		t.Errorf("Token returned %+v, %v", token, err)
+	}
+}
+
+func TestConfig_AuthCode_signInError(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	_, err := f.config().AuthCode(context.Background(), func(rawURL string) error {
+		authURL, err := url.Parse(rawURL)
+		if err != nil {
+			return err
+		}
+
+		query := authURL.Query()
+
+		// A request without the state of the sign-in is ignored.
+		resp, err := http.Get(query.Get("redirect_uri") + "?code=forged")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		redirect := url.Values{"error": {"access_denied"}, "error_description": {"The user has declined."}, "state": {query.Get("state")}}
+		resp, err = http.Get(query.Get("redirect_uri") + "?" + redirect.Encode())
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		return nil
+	}, nil)
+
+	var authError *Error
+	if !errors.As(err, &authError) || authError.Code != "access_denied" {
+		t.Errorf("AuthCode returned error %v, want access_denied", err)
+	}
+}
+
+func TestConfig_AuthCode_canceled(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := f.config().AuthCode(ctx, func(authURL string) error { return nil }, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("AuthCode returned error %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	// clientAssertionLifetime is how long a client assertion is valid after it is signed.
+	clientAssertionLifetime = 10 * time.Minute
+)
+
+// ClientCertificate represents the certificate of a confidential client, whose public key has been uploaded
+// to the app registration, along with its private key.
+type ClientCertificate struct {
+	Certificate *x509.Certificate
+	PrivateKey  *rsa.PrivateKey
+}
+
+// ClientCredentials returns a token source which acquires the tokens of the application itself with the
+// client credentials flow, i.e. without a signed-in user. The client authenticates with its Certificate, if
+// any, or with its ClientSecret. The tokens grant the application permissions consented for the app
+// registration in the Tenant, which must be a specific tenant, and they are not saved to the Store.
+//
+// As there is no signed-in user, the OneDrive client must access the drives with Client.ForUser or with
+// explicit drive IDs.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-client-creds-grant-flow
+func (c *Config) ClientCredentials(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.ClientSecret == "" && c.Certificate == nil {
+		return nil, errors.New("Please provide the client secret or the certificate of the client.")
+	}
+
+	if c.Certificate != nil && (c.Certificate.Certificate == nil || c.Certificate.PrivateKey == nil) {
+		return nil, errors.New("Please provide both the certificate and its private key.")
+	}
+
+	return oauth2.ReuseTokenSource(nil, &clientCredentialsTokenSource{ctx: ctx, config: c}), nil
+}
+
+// clientCredentialsTokenSource acquires a new token with the client credentials each time it is called.
+type clientCredentialsTokenSource struct {
+	ctx    context.Context
+	config *Config
+}
+
+func (s *clientCredentialsTokenSource) Token() (*oauth2.Token, error) {
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {AppScope},
+	}
+
+	if s.config.Certificate != nil {
+		assertion, err := s.config.clientAssertion()
+		if err != nil {
+			return nil, err
+		}
+
+		form.Set("client_assertion_type", clientAssertionType)
+		form.Set("client_assertion", assertion)
+	}
+
+	return s.config.requestToken(s.ctx, form)
+}
+
+// clientAssertion returns a JSON Web Token signed with the private key of the client certificate, which
+// proves the identity of the client to the token endpoint.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+func (c *Config) clientAssertion() (string, error) {
+	thumbprint := sha1.Sum(c.Certificate.Certificate.Raw)
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	jwtId, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": c.endpointURL("token"),
+		"iss": c.ClientId,
+		"sub": c.ClientId,
+		"jti": jwtId,
+		"nbf": now.Unix(),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.Certificate.PrivateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
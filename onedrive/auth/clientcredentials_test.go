@@ -0,0 +1,103 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// newTestCertificate returns a self-signed certificate of a new RSA key.
+func newTestCertificate(t *testing.T) *ClientCertificate {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "go-onedrive test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ClientCertificate{Certificate: certificate, PrivateKey: key}
+}
+
+func TestConfig_ClientCredentials_secret(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	config := f.config()
+	config.ClientSecret = testClientSecret
+
+	ts, err := config.ClientCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("ClientCredentials returned error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := ts.Token()
+		if err != nil || token.AccessToken != "app-access-1" {
+			t.Errorf("Token returned %+v, %v, want the same token until it expires", token, err)
+		}
+	}
+
+	config.ClientSecret = "wrong"
+	ts, _ = config.ClientCredentials(context.Background())
+
+	var authError *Error
+	if _, err := ts.Token(); !errors.As(err, &authError) || authError.Code != "invalid_client" {
+		t.Errorf("Token returned error %v, want invalid_client", err)
+	}
+}
+
+func TestConfig_ClientCredentials_certificate(t *testing.T) {
+	f := newFakeIdentityPlatform(t)
+	defer f.server.Close()
+
+	certificate := newTestCertificate(t)
+	f.certificateKey = &certificate.PrivateKey.PublicKey
+
+	config := f.config()
+	config.Certificate = certificate
+
+	ts, err := config.ClientCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("ClientCredentials returned error: %v", err)
+	}
+
+	token, err := ts.Token()
+	if err != nil || token.AccessToken != "app-access-1" {
+		t.Errorf("Token returned %+v, %v", token, err)
+	}
+
+	if f.lastTokenRequest.Get("client_secret") != "" {
+		t.Errorf("Token request has a client secret along with the client assertion")
+	}
+
+	if _, err := (&Config{ClientId: testClientId}).ClientCredentials(context.Background()); err == nil {
+		t.Errorf("ClientCredentials without credentials returned no error")
+	}
+}
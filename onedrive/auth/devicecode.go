@@ -0,0 +1,92 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultPollInterval is the interval of polling the token endpoint when the device code response does not
+// specify one, which is also added to the interval when the token endpoint asks to slow down.
+var defaultPollInterval = 5 * time.Second
+
+// DeviceCodeResponse represents the JSON object returned by the Microsoft identity platform when the device
+// code flow is started. The user signs in by entering the UserCode at the VerificationURI on another device.
+type DeviceCodeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"` // Seconds before the device code expires.
+	Interval        int    `json:"interval"`   // Seconds between the polls of the token endpoint.
+	Message         string `json:"message"`    // Instructions for the user, including the code and the URI.
+}
+
+// DeviceCode signs in a user with the device code flow, which suits the apps without a browser, e.g.
+// command-line tools running over SSH. The prompt is called with the code to be shown to the user, and
+// the token endpoint is then polled until the user has signed in, the code has expired or ctx is done.
+//
+// Microsoft identity platform docs: https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-device-code
+func (c *Config) DeviceCode(ctx context.Context, prompt func(code *DeviceCodeResponse) error) (oauth2.TokenSource, error) {
+	if prompt == nil {
+		return nil, errors.New("Please provide the prompt showing the code to the user.")
+	}
+
+	var code DeviceCodeResponse
+	err := c.post(ctx, "devicecode", url.Values{"client_id": {c.ClientId}, "scope": {c.scopes()}}, &code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prompt(&code); err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	expiry := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		token, err := c.requestToken(ctx, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {code.DeviceCode},
+		})
+		if err == nil {
+			return c.newUserTokenSource(ctx, token)
+		}
+
+		var authError *Error
+		if !errors.As(err, &authError) {
+			return nil, err
+		}
+
+		switch authError.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += defaultPollInterval
+		default:
+			return nil, err
+		}
+
+		if code.ExpiresIn > 0 && time.Now().After(expiry) {
+			return nil, &Error{Code: "expired_token", Description: "The device code has expired before the user signed in."}
+		}
+	}
+}
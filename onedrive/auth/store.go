@@ -0,0 +1,159 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenStore saves the tokens acquired on behalf of a user, so that they can be reused the next time.
+// A TokenStore may be implemented to save the tokens elsewhere, e.g. in the keychain of the system.
+type TokenStore interface {
+	// Load returns the saved token, or ErrTokenNotFound if no token has been saved yet.
+	Load() (*oauth2.Token, error)
+
+	// Save saves the token, replacing the previous one.
+	Save(token *oauth2.Token) error
+}
+
+// FileStore saves the token as JSON in a file readable by the current user only. The file has the same format
+// as the tokens of golang.org/x/oauth2, i.e. with the access_token, token_type, refresh_token and expiry.
+type FileStore struct {
+	Path string
+}
+
+// NewFileStore returns a FileStore saving the token in the file at the given path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+// Load returns the token saved in the file, or ErrTokenNotFound if the file does not exist.
+func (s *FileStore) Load() (*oauth2.Token, error) {
+	content, err := readFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var token *oauth2.Token
+	if err := json.Unmarshal(content, &token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Save saves the token in the file.
+func (s *FileStore) Save(token *oauth2.Token) error {
+	content, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(s.Path, content)
+}
+
+// EncryptedFileStore saves the token as JSON in a file encrypted with AES-GCM, so that the token cannot be
+// read, or tampered with, without the key, e.g. a key kept in a secret manager rather than on the disk.
+type EncryptedFileStore struct {
+	Path string
+	aead cipher.AEAD
+}
+
+// NewEncryptedFileStore returns an EncryptedFileStore saving the token in the file at the given path, encrypted
+// with the given AES key, which must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewEncryptedFileStore(path string, key []byte) (*EncryptedFileStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedFileStore{Path: path, aead: aead}, nil
+}
+
+// Load decrypts and returns the token saved in the file, or ErrTokenNotFound if the file does not exist.
+func (s *EncryptedFileStore) Load() (*oauth2.Token, error) {
+	content, err := readFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(content) < nonceSize {
+		return nil, errors.New("The token file is not encrypted by EncryptedFileStore.")
+	}
+
+	plaintext, err := s.aead.Open(nil, content[:nonceSize], content[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.New("The token file cannot be decrypted with the key: " + err.Error())
+	}
+
+	var token *oauth2.Token
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Save encrypts the token with a new random nonce and saves it in the file, preceded by the nonce.
+func (s *EncryptedFileStore) Save(token *oauth2.Token) error {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return writeFile(s.Path, s.aead.Seal(nonce, nonce, plaintext, nil))
+}
+
+// readFile returns the content of the file, or ErrTokenNotFound if the file does not exist.
+func readFile(path string) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrTokenNotFound
+	}
+
+	return content, err
+}
+
+// writeFile replaces the content of the file, readable by the current user only, through a temporary file in
+// the same folder, so that the previous token is kept if the new one cannot be written completely.
+func writeFile(path string, content []byte) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	// ioutil.TempFile creates the file with the 0600 permissions already.
+	return os.Rename(tempFile.Name(), path)
+}
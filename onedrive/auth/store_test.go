@@ -0,0 +1,104 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// testStore saves a token to the store and verifies that it is loaded back.
+func testStore(t *testing.T, store TokenStore) {
+	t.Helper()
+
+	if _, err := store.Load(); err != ErrTokenNotFound {
+		t.Fatalf("Load returned error %v, want ErrTokenNotFound", err)
+	}
+
+	want := &oauth2.Token{
+		AccessToken:  "access-token",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Date(2020, 11, 8, 10, 0, 0, 0, time.UTC),
+	}
+
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken || !got.Expiry.Equal(want.Expiry) {
+		t.Errorf("Load returned %+v, want %+v", got, want)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	tokenPath := filepath.Join(dir, "token.json")
+	testStore(t, NewFileStore(tokenPath))
+
+	info, err := os.Stat(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Token file has permissions %v, want 0600", info.Mode().Perm())
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Folder has %d files, want only the token file", len(files))
+	}
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	key := bytes.Repeat([]byte{0x42}, 32)
+	tokenPath := filepath.Join(dir, "token.enc")
+
+	store, err := NewEncryptedFileStore(tokenPath, key)
+	if err != nil {
+		t.Fatalf("NewEncryptedFileStore returned error: %v", err)
+	}
+
+	testStore(t, store)
+
+	content, err := ioutil.ReadFile(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(content, []byte("access-token")) || bytes.Contains(content, []byte("refresh-token")) {
+		t.Errorf("Token file contains the tokens in plain text")
+	}
+
+	otherStore, err := NewEncryptedFileStore(tokenPath, bytes.Repeat([]byte{0x24}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := otherStore.Load(); err == nil {
+		t.Errorf("Load with another key returned no error")
+	}
+
+	if _, err := NewEncryptedFileStore(tokenPath, []byte("short")); err == nil {
+		t.Errorf("NewEncryptedFileStore with an invalid key returned no error")
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// BundlesService handles communication with the bundles, including the photo albums, related methods of the OneDrive API.
+//
+// Bundles are only supported by OneDrive personal. Hence, all the methods work on the default drive of the user of the client.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/bundle?view=odsp-graph-online
+type BundlesService service
+
+// Bundle represents the bundle facet of a drive item, which groups a set of items together.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/bundle?view=odsp-graph-online
+type Bundle struct {
+	ChildCount int    `json:"childCount,omitempty"` // Read-only.
+	Album      *Album `json:"album,omitempty"`      // Only present when the bundle is a photo album.
+}
+
+// Album represents the album facet of a bundle.
+type Album struct {
+	CoverImageItemId string `json:"coverImageItemId,omitempty"`
+}
+
+// CreateBundleRequest represents the JSON object sent to the OneDrive API to create a bundle.
+type CreateBundleRequest struct {
+	Name             string             `json:"name"`
+	ConflictBehavior string             `json:"@microsoft.graph.conflictBehavior"`
+	Bundle           *Bundle            `json:"bundle"`
+	Children         []*BundleChildItem `json:"children"`
+}
+
+// BundleChildItem represents an item referenced by its ID when it is added to a bundle.
+type BundleChildItem struct {
+	Id string `json:"id"`
+}
+
+// RenameBundleRequest represents the JSON object sent to the OneDrive API to rename a bundle.
+type RenameBundleRequest struct {
+	Name string `json:"name"`
+}
+
+// Create a bundle containing the items with the given IDs. If isAlbum is true, the bundle will be a photo album.
+// If there is already a bundle with the same name, OneDrive will choose a new name for the bundle while creating it.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_create?view=odsp-graph-online
+func (s *BundlesService) Create(ctx context.Context, name string, itemIds []string, isAlbum bool) (*DriveItem, error) {
+	if name == "" {
+		return nil, errors.New("Please provide the name of the bundle.")
+	}
+
+	if len(itemIds) == 0 {
+		return nil, errors.New("Please provide the IDs of the items in the bundle.")
+	}
+
+	body := &CreateBundleRequest{
+		Name:             name,
+		ConflictBehavior: "rename",
+		Bundle:           &Bundle{},
+	}
+
+	if isAlbum {
+		body.Bundle.Album = &Album{}
+	}
+
+	for _, itemId := range itemIds {
+		body.Children = append(body.Children, &BundleChildItem{Id: itemId})
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, s.client.driveURL("")+"/bundles", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// Get a bundle by its ID.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_get?view=odsp-graph-online
+func (s *BundlesService) Get(ctx context.Context, bundleId string) (*DriveItem, error) {
+	if bundleId == "" {
+		return nil, errors.New("Please provide the ID of the bundle.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, s.client.driveURL("")+"/bundles/"+url.PathEscape(bundleId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// List the bundles in the default drive of the user of the client. If albumsOnly is true, only the photo albums will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_list?view=odsp-graph-online
+func (s *BundlesService) List(ctx context.Context, albumsOnly bool) (*OneDriveDriveItemsResponse, error) {
+	filter := "bundle ne null"
+	if albumsOnly {
+		filter = "bundle/album ne null"
+	}
+
+	apiURL := s.client.driveURL("") + "/bundles?" + url.Values{"filter": {filter}}.Encode()
+
+	req, err := s.client.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// ListChildren lists the items in a bundle.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_get?view=odsp-graph-online#get-a-bundle-and-its-children-in-a-single-call
+func (s *BundlesService) ListChildren(ctx context.Context, bundleId string) (*OneDriveDriveItemsResponse, error) {
+	if bundleId == "" {
+		return nil, errors.New("Please provide the ID of the bundle.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, s.client.driveURL("")+"/bundles/"+url.PathEscape(bundleId)+"/children", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// AddItem adds an item to a bundle. The OneDrive API responds with no content when the item has been added.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_additem?view=odsp-graph-online
+func (s *BundlesService) AddItem(ctx context.Context, bundleId string, itemId string) error {
+	if bundleId == "" {
+		return errors.New("Please provide the ID of the bundle.")
+	}
+
+	if itemId == "" {
+		return errors.New("Please provide the ID of the item to be added.")
+	}
+
+	apiURL := s.client.driveURL("") + "/bundles/" + url.PathEscape(bundleId) + "/children"
+
+	req, err := s.client.NewRequest(http.MethodPost, apiURL, &BundleChildItem{Id: itemId})
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(ctx, req, false, nil)
+}
+
+// RemoveItem removes an item from a bundle. The item itself will not be deleted.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_removeitem?view=odsp-graph-online
+func (s *BundlesService) RemoveItem(ctx context.Context, bundleId string, itemId string) error {
+	if bundleId == "" {
+		return errors.New("Please provide the ID of the bundle.")
+	}
+
+	if itemId == "" {
+		return errors.New("Please provide the ID of the item to be removed.")
+	}
+
+	apiURL := s.client.driveURL("") + "/bundles/" + url.PathEscape(bundleId) + "/children/" + url.PathEscape(itemId)
+
+	req, err := s.client.NewRequest(http.MethodDelete, apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(ctx, req, false, nil)
+}
+
+// Rename a bundle.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_update?view=odsp-graph-online
+func (s *BundlesService) Rename(ctx context.Context, bundleId string, newName string) (*DriveItem, error) {
+	if bundleId == "" {
+		return nil, errors.New("Please provide the ID of the bundle.")
+	}
+
+	if newName == "" {
+		return nil, errors.New("Please provide the new name of the bundle.")
+	}
+
+	apiURL := s.client.driveURL("") + "/bundles/" + url.PathEscape(bundleId)
+
+	req, err := s.client.NewRequest(http.MethodPatch, apiURL, &RenameBundleRequest{Name: newName})
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// Delete a bundle. The items in the bundle will not be deleted.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/bundle_delete?view=odsp-graph-online
+func (s *BundlesService) Delete(ctx context.Context, bundleId string) error {
+	if bundleId == "" {
+		return errors.New("Please provide the ID of the bundle.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodDelete, s.client.driveURL("")+"/items/"+url.PathEscape(bundleId), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(ctx, req, false, nil)
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBundlesService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_bundle.json")
+	mux.HandleFunc("/me/drive/bundles", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		testBody(t, r, `{"name":"Vacation in Hawaii","@microsoft.graph.conflictBehavior":"rename","bundle":{"album":{}},"children":[{"id":"1234"},{"id":"1235"}]}`)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.Bundles.Create(ctx, "Vacation in Hawaii", []string{"1234", "1235"}, true)
+	if err != nil {
+		t.Fatalf("Bundles.Create returned error: %v", err)
+	}
+
+	var wantDriveItem *DriveItem
+	json.Unmarshal(jsonData, &wantDriveItem)
+
+	if !reflect.DeepEqual(gotDriveItem, wantDriveItem) {
+		t.Errorf("Bundles.Create returned %+v, want %+v", gotDriveItem, wantDriveItem)
+	}
+
+	if !AlbumFacet.hasFacet(gotDriveItem) || gotDriveItem.Bundle.Album.CoverImageItemId != "1234" {
+		t.Errorf("Bundles.Create returned %+v, want an album", gotDriveItem.Bundle)
+	}
+
+	if _, err := client.Bundles.Create(ctx, "Empty", nil, false); err == nil {
+		t.Errorf("Bundles.Create returned no error for a bundle without items")
+	}
+}
+
+func TestBundlesService_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var gotFilters []string
+	mux.HandleFunc("/me/drive/bundles", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		gotFilters = append(gotFilters, r.FormValue("filter"))
+
+		fmt.Fprint(w, `{"value": [`+string(getTestDataFromFile(t, "fake_bundle.json"))+`]}`)
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Bundles.List(ctx, false)
+	if err != nil {
+		t.Fatalf("Bundles.List returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.DriveItems) != 1 || !BundleFacet.hasFacet(gotOneDriveResponse.DriveItems[0]) {
+		t.Errorf("Bundles.List returned %+v, want one bundle", gotOneDriveResponse)
+	}
+
+	if _, err := client.Bundles.List(ctx, true); err != nil {
+		t.Fatalf("Bundles.List returned error: %v", err)
+	}
+
+	wantFilters := []string{"bundle ne null", "bundle/album ne null"}
+	if !reflect.DeepEqual(gotFilters, wantFilters) {
+		t.Errorf("Bundles.List requested the filters %q, want %q", gotFilters, wantFilters)
+	}
+}
+
+func TestBundlesService_Children(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/bundles/bundle1/children", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"value": [{"id": "1234"}, {"id": "1235"}]}`)
+		case http.MethodPost:
+			var gotRequest *BundleChildItem
+			json.NewDecoder(r.Body).Decode(&gotRequest)
+
+			if gotRequest.Id != "1236" {
+				t.Errorf("Request body = %+v, want the item 1236", gotRequest)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request method %v", r.Method)
+		}
+	})
+
+	mux.HandleFunc("/me/drive/bundles/bundle1/children/1234", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Bundles.ListChildren(ctx, "bundle1")
+	if err != nil {
+		t.Fatalf("Bundles.ListChildren returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.DriveItems) != 2 {
+		t.Errorf("Bundles.ListChildren returned %d items, want 2", len(gotOneDriveResponse.DriveItems))
+	}
+
+	if err := client.Bundles.AddItem(ctx, "bundle1", "1236"); err != nil {
+		t.Errorf("Bundles.AddItem returned error: %v", err)
+	}
+
+	if err := client.Bundles.RemoveItem(ctx, "bundle1", "1234"); err != nil {
+		t.Errorf("Bundles.RemoveItem returned error: %v", err)
+	}
+}
+
+func TestBundlesService_RenameAndDelete(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/bundles/bundle1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+
+		var gotRequest *RenameBundleRequest
+		json.NewDecoder(r.Body).Decode(&gotRequest)
+
+		fmt.Fprintf(w, `{"id": "bundle1", "name": %q, "bundle": {"childCount": 2}}`, gotRequest.Name)
+	})
+
+	mux.HandleFunc("/me/drive/items/bundle1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.Bundles.Rename(ctx, "bundle1", "Hawaii 2020")
+	if err != nil {
+		t.Fatalf("Bundles.Rename returned error: %v", err)
+	}
+
+	if gotDriveItem.Name != "Hawaii 2020" {
+		t.Errorf("Bundles.Rename returned %+v, want the renamed bundle", gotDriveItem)
+	}
+
+	if err := client.Bundles.Delete(ctx, "bundle1"); err != nil {
+		t.Errorf("Bundles.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"strings"
+)
+
+// DownloadFormat the possible values of the format which the content of an item is converted to when it is downloaded
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content_format?view=odsp-graph-online#format-options
+type DownloadFormat int
+
+const (
+	PDFFormat DownloadFormat = iota
+	HTMLFormat
+	JPGFormat
+	GLBFormat
+)
+
+var downloadFormatNames = [...]string{"pdf", "html", "jpg", "glb"}
+
+func (downloadFormat DownloadFormat) toString() string {
+	if downloadFormat < 0 || int(downloadFormat) >= len(downloadFormatNames) {
+		return ""
+	}
+
+	return downloadFormatNames[downloadFormat]
+}
+
+// officeDocumentMIMETypes are the MIME types of the documents which can be converted to both PDF and JPG.
+var officeDocumentMIMETypes = []string{
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.",
+	"application/vnd.ms-word.",
+	"application/vnd.ms-excel",
+	"application/vnd.ms-excel.",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.ms-powerpoint.",
+	"application/vnd.openxmlformats-officedocument.presentationml.",
+	"application/vnd.oasis.opendocument.",
+	"application/rtf",
+	"application/epub+zip",
+	"application/vnd.ms-outlook",
+	"message/rfc822",
+	"text/html",
+	"text/markdown",
+	"image/tiff",
+}
+
+// convertibleMIMETypes are the MIME types, or the prefixes of the MIME types, of the files which can be
+// converted to each of the download formats.
+var convertibleMIMETypes = map[DownloadFormat][]string{
+	PDFFormat: officeDocumentMIMETypes,
+	HTMLFormat: {
+		"application/loop",
+		"application/fluid",
+		"application/vnd.microsoft.whiteboard",
+	},
+	JPGFormat: append([]string{
+		"image/",
+		"video/",
+		"audio/",
+		"text/",
+		"application/pdf",
+		"application/postscript",
+		"application/json",
+		"application/xml",
+		"model/",
+	}, officeDocumentMIMETypes...),
+	GLBFormat: {
+		"model/",
+		"application/vnd.ms-pki.stl",
+		"application/sla",
+	},
+}
+
+// canConvertFrom reports whether a file of the given MIME type can be converted to the download format.
+// A file whose MIME type is unknown, i.e. empty or "application/octet-stream", is assumed to be convertible
+// and left to the OneDrive API to decide.
+func (downloadFormat DownloadFormat) canConvertFrom(mimeType string) bool {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if i := strings.Index(mimeType, ";"); i >= 0 {
+		mimeType = strings.TrimSpace(mimeType[:i])
+	}
+
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		return true
+	}
+
+	for _, convertibleMIMEType := range convertibleMIMETypes[downloadFormat] {
+		if strings.HasSuffix(convertibleMIMEType, "/") || strings.HasSuffix(convertibleMIMEType, ".") {
+			if strings.HasPrefix(mimeType, convertibleMIMEType) {
+				return true
+			}
+		} else if mimeType == convertibleMIMEType {
+			return true
+		}
+	}
+
+	return false
+}
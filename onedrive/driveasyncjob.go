@@ -4,7 +4,18 @@
 
 package onedrive
 
-import "context"
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultWaitInitialInterval = 1 * time.Second
+	defaultWaitMaxInterval     = 30 * time.Second
+)
 
 // DriveAsyncJobService handles communication with the drive items searching related methods of the OneDrive API.
 //
@@ -18,23 +29,168 @@ type OneDriveAsyncJobMonitorResponse struct {
 	Operation           string  `json:"operation"`
 	Status              string  `json:"status"`
 	StatusDescription   string  `json:"statusDescription"`
-	PercentageCompleted float64 `json:"percentageCompleted"`
+	PercentageCompleted float64 `json:"percentageComplete"`
+}
+
+// WaitOptions represents the settings of waiting for an async job to complete.
+type WaitOptions struct {
+	// InitialInterval is the interval before polling the monitor URL again. Defaults to 1 second.
+	InitialInterval time.Duration
+
+	// MaxInterval is the maximum interval which the polling interval backs off to. Defaults to 30 seconds.
+	// The interval requested by the Retry-After header of a monitor response is always honored.
+	MaxInterval time.Duration
+
+	// Progress receives each of the status reports retrieved from the monitor URL.
+	Progress chan<- *OneDriveAsyncJobMonitorResponse
+}
+
+// AsyncJobError is returned when an async job ends in failure.
+type AsyncJobError struct {
+	Status            string
+	ErrorCode         string
+	Operation         string
+	StatusDescription string
+}
+
+func (e *AsyncJobError) Error() string {
+	message := "The async job " + e.Operation + " has " + e.Status
+	if e.ErrorCode != "" {
+		message += ": " + e.ErrorCode
+	}
+
+	if e.StatusDescription != "" {
+		message += " (" + e.StatusDescription + ")"
+	}
+
+	return message
 }
 
 // Retrieve a status report from the monitor URL of OneDrive.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/long-running-actions?view=odsp-graph-online#retrieve-a-status-report-from-the-monitor-url
 func (s *DriveAsyncJobService) Monitor(ctx context.Context, monitorUrl string) (*OneDriveAsyncJobMonitorResponse, error) {
+	oneDriveResponse, _, err := s.monitor(ctx, monitorUrl)
+
+	return oneDriveResponse, err
+}
+
+func (s *DriveAsyncJobService) monitor(ctx context.Context, monitorUrl string) (*OneDriveAsyncJobMonitorResponse, *http.Response, error) {
 	req, err := s.client.NewRequestToOneDrive("GET", monitorUrl, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var oneDriveResponse *OneDriveAsyncJobMonitorResponse
-	err = s.client.Do(ctx, req, true, &oneDriveResponse)
+	resp, err := s.client.do(ctx, req, true, &oneDriveResponse)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
+	}
+
+	return oneDriveResponse, resp, nil
+}
+
+// Wait polls the monitor URL of an async job until the job ends, backing off between the polls, and then
+// returns the drive item which is the result of the job, e.g. the new item created by a copy.
+//
+// If the job ends in failure, an *AsyncJobError will be returned.
+//
+// If driveId is empty, it means the resulting item is in the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/long-running-actions?view=odsp-graph-online
+func (s *DriveAsyncJobService) Wait(ctx context.Context, driveId string, monitorUrl string, options *WaitOptions) (*DriveItem, error) {
+	if monitorUrl == "" {
+		return nil, errors.New("Please provide the monitor URL of the async job.")
+	}
+
+	if options == nil {
+		options = &WaitOptions{}
+	}
+
+	interval := options.InitialInterval
+	if interval <= 0 {
+		interval = defaultWaitInitialInterval
+	}
+
+	maxInterval := options.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitMaxInterval
+	}
+
+	for {
+		status, resp, err := s.monitor(ctx, monitorUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		if options.Progress != nil {
+			select {
+			case options.Progress <- status:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		switch status.Status {
+		case "completed":
+			if status.ResourceId == "" {
+				return nil, errors.New("The async job has completed without returning the ID of the resulting item.")
+			}
+
+			return (*DriveItemsService)(s).GetInDrive(ctx, driveId, status.ResourceId)
+		case "failed", "deleteFailed", "cancelled":
+			return nil, &AsyncJobError{
+				Status:            status.Status,
+				ErrorCode:         status.ErrorCode,
+				Operation:         status.Operation,
+				StatusDescription: status.StatusDescription,
+			}
+		}
+
+		delay := interval
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			delay = retryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// parseRetryAfter returns the delay requested by the Retry-After header of the given response, if any.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if retryTime, err := http.ParseTime(retryAfter); err == nil {
+		delay := time.Until(retryTime)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
 	}
 
-	return oneDriveResponse, nil
+	return 0, false
 }
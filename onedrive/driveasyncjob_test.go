@@ -7,16 +7,18 @@ package onedrive
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestDriveAsyncJobService_Monitor_SuccessFile(t *testing.T) {
-	client, mux, _, teardown := setup()
+	client, mux, serverURL, teardown := setup()
 
 	defer teardown()
 
@@ -29,7 +31,7 @@ func TestDriveAsyncJobService_Monitor_SuccessFile(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	gotOneDriveResponse, err := client.DriveAsyncJob.Monitor(ctx, "/test-onedrive-api/monitor/asyncJobSuccessFile")
+	gotOneDriveResponse, err := client.DriveAsyncJob.Monitor(ctx, serverURL+baseOneDriveURLPath+"/monitor/asyncJobSuccessFile")
 	if err != nil {
 		t.Errorf("DriveItems.Monitor returned error: %v", err)
 	}
@@ -58,7 +60,7 @@ func TestDriveAsyncJobService_Monitor_SuccessFile(t *testing.T) {
 }
 
 func TestDriveAsyncJobService_Monitor_SuccessFolder(t *testing.T) {
-	client, mux, _, teardown := setup()
+	client, mux, serverURL, teardown := setup()
 
 	defer teardown()
 
@@ -71,7 +73,7 @@ func TestDriveAsyncJobService_Monitor_SuccessFolder(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	gotOneDriveResponse, err := client.DriveAsyncJob.Monitor(ctx, "/test-onedrive-api/monitor/asyncJobSuccessFolder")
+	gotOneDriveResponse, err := client.DriveAsyncJob.Monitor(ctx, serverURL+baseOneDriveURLPath+"/monitor/asyncJobSuccessFolder")
 	if err != nil {
 		t.Errorf("DriveItems.Monitor returned error: %v", err)
 	}
@@ -100,7 +102,7 @@ func TestDriveAsyncJobService_Monitor_SuccessFolder(t *testing.T) {
 }
 
 func TestDriveAsyncJobService_Monitor_Failed(t *testing.T) {
-	client, mux, _, teardown := setup()
+	client, mux, serverURL, teardown := setup()
 
 	defer teardown()
 
@@ -113,7 +115,7 @@ func TestDriveAsyncJobService_Monitor_Failed(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	gotOneDriveResponse, err := client.DriveAsyncJob.Monitor(ctx, "/test-onedrive-api/monitor/asyncJobFailed")
+	gotOneDriveResponse, err := client.DriveAsyncJob.Monitor(ctx, serverURL+baseOneDriveURLPath+"/monitor/asyncJobFailed")
 	if err != nil {
 		t.Errorf("DriveItems.Monitor returned error: %v", err)
 	}
@@ -140,3 +142,186 @@ func TestDriveAsyncJobService_Monitor_Failed(t *testing.T) {
 	}
 
 }
+
+func TestDriveAsyncJobService_Wait(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	pollCount := 0
+	mux.HandleFunc("/monitor/asyncJob", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Monitor request should be unauthenticated")
+		}
+
+		pollCount++
+		if pollCount < 3 {
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"operation": "itemCopy", "percentageComplete": %v, "status": "inProgress"}`, pollCount*40)
+			return
+		}
+
+		jsonData := getTestDataFromFile(t, "fake_asyncJobSuccessFile.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	mux.HandleFunc("/me/drive/items/0000000000000002!2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_driveItem.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	progress := make(chan *OneDriveAsyncJobMonitorResponse, 10)
+	options := &WaitOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Progress:        progress,
+	}
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveAsyncJob.Wait(ctx, "", serverURL+baseOneDriveURLPath+"/monitor/asyncJob", options)
+	if err != nil {
+		t.Fatalf("DriveAsyncJob.Wait returned error: %v", err)
+	}
+
+	var wantDriveItem *DriveItem
+	json.Unmarshal(getTestDataFromFile(t, "fake_driveItem.json"), &wantDriveItem)
+
+	if !reflect.DeepEqual(gotDriveItem, wantDriveItem) {
+		t.Errorf("DriveAsyncJob.Wait returned %+v, want %+v", gotDriveItem, wantDriveItem)
+	}
+
+	close(progress)
+
+	var gotPercentages []float64
+	for status := range progress {
+		gotPercentages = append(gotPercentages, status.PercentageCompleted)
+	}
+
+	if wantPercentages := []float64{40, 80, 100}; !reflect.DeepEqual(gotPercentages, wantPercentages) {
+		t.Errorf("DriveAsyncJob.Wait reported progress %v, want %v", gotPercentages, wantPercentages)
+	}
+}
+
+func TestDriveAsyncJobService_Wait_RetryAfter(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	isFirstPoll := true
+	mux.HandleFunc("/monitor/asyncJob", func(w http.ResponseWriter, r *http.Request) {
+		if isFirstPoll {
+			isFirstPoll = false
+
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"operation": "itemCopy", "status": "inProgress"}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"operation": "itemCopy", "resourceId": "1", "status": "completed"}`)
+	})
+
+	mux.HandleFunc("/drives/drive1/items/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "1"}`)
+	})
+
+	ctx := context.Background()
+	startTime := time.Now()
+	_, err := client.DriveAsyncJob.Wait(ctx, "drive1", serverURL+baseOneDriveURLPath+"/monitor/asyncJob", &WaitOptions{InitialInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("DriveAsyncJob.Wait returned error: %v", err)
+	}
+
+	if elapsed := time.Since(startTime); elapsed < time.Second {
+		t.Errorf("DriveAsyncJob.Wait polled again after %v, want at least 1s as requested by Retry-After", elapsed)
+	}
+}
+
+func TestDriveAsyncJobService_Wait_Failed(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/monitor/asyncJobFailed", func(w http.ResponseWriter, r *http.Request) {
+		jsonData := getTestDataFromFile(t, "fake_asyncJobFailed.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	_, err := client.DriveAsyncJob.Wait(ctx, "", serverURL+baseOneDriveURLPath+"/monitor/asyncJobFailed", nil)
+
+	var asyncJobError *AsyncJobError
+	if !errors.As(err, &asyncJobError) {
+		t.Fatalf("DriveAsyncJob.Wait returned error %v, want an *AsyncJobError", err)
+	}
+
+	if asyncJobError.ErrorCode != "RelationshipNameAlreadyExists_1629.ce04" {
+		t.Errorf("DriveAsyncJob.Wait returned error code %q, want %q", asyncJobError.ErrorCode, "RelationshipNameAlreadyExists_1629.ce04")
+	}
+}
+
+func TestDriveItemsService_CopyAndWait(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/drive1/items/1/copy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		w.Header().Set("Location", serverURL+baseOneDriveURLPath+"/monitor/asyncJobSuccessFolder")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("/monitor/asyncJobSuccessFolder", func(w http.ResponseWriter, r *http.Request) {
+		jsonData := getTestDataFromFile(t, "fake_asyncJobSuccessFolder.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	mux.HandleFunc("/drives/drive2/items/0000000000000001!1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "0000000000000001!1", "name": "Copied"}`)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.CopyAndWait(ctx, "drive1", "1", "drive2", "2", "Copied", nil)
+	if err != nil {
+		t.Fatalf("DriveItems.CopyAndWait returned error: %v", err)
+	}
+
+	if gotDriveItem.Id != "0000000000000001!1" || gotDriveItem.Name != "Copied" {
+		t.Errorf("DriveItems.CopyAndWait returned %+v, want the copied item", gotDriveItem)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testCases := []struct {
+		retryAfter string
+		wantDelay  time.Duration
+		wantOk     bool
+	}{
+		{retryAfter: "", wantOk: false},
+		{retryAfter: "120", wantDelay: 120 * time.Second, wantOk: true},
+		{retryAfter: "-1", wantOk: false},
+		{retryAfter: "soon", wantOk: false},
+		{retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", wantDelay: 0, wantOk: true},
+	}
+
+	for _, testCase := range testCases {
+		resp := &http.Response{Header: http.Header{}}
+		if testCase.retryAfter != "" {
+			resp.Header.Set("Retry-After", testCase.retryAfter)
+		}
+
+		gotDelay, gotOk := parseRetryAfter(resp)
+		if gotDelay != testCase.wantDelay || gotOk != testCase.wantOk {
+			t.Errorf("parseRetryAfter(%q) returned (%v, %v), want (%v, %v)", testCase.retryAfter, gotDelay, gotOk, testCase.wantDelay, testCase.wantOk)
+		}
+	}
+}
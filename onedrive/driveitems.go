@@ -12,8 +12,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/h2non/filetype"
 )
@@ -33,18 +36,53 @@ type OneDriveDriveItemsResponse struct {
 // DriveItem represents a OneDrive drive item.
 // Ref https://docs.microsoft.com/en-us/graph/api/resources/driveitem?view=graph-rest-1.0
 type DriveItem struct {
-	Name        string           `json:"name"`
-	Id          string           `json:"id"`
-	DownloadURL string           `json:"@microsoft.graph.downloadUrl"`
-	Description string           `json:"description"`
-	Size        int64            `json:"size"`
-	WebURL      string           `json:"webUrl"`
-	Audio       *OneDriveAudio   `json:"audio"`
-	Video       *OneDriveVideo   `json:"video"`
-	Image       *OneDriveImage   `json:"image"`
-	Photo       *OneDrivePhoto   `json:"photo"`
-	File        *DriveItemFile   `json:"file"`
-	Folder      *DriveItemFolder `json:"folder"`
+	Name                 string           `json:"name"`
+	Id                   string           `json:"id"`
+	DownloadURL          string           `json:"@microsoft.graph.downloadUrl"`
+	ETag                 string           `json:"eTag"`
+	CTag                 string           `json:"cTag"`
+	Description          string           `json:"description"`
+	CreatedDateTime      time.Time        `json:"createdDateTime"`
+	LastModifiedDateTime time.Time        `json:"lastModifiedDateTime"`
+	Size                 int64            `json:"size"`
+	WebURL               string           `json:"webUrl"`
+	Audio                *OneDriveAudio   `json:"audio"`
+	Video                *OneDriveVideo   `json:"video"`
+	Image                *OneDriveImage   `json:"image"`
+	Photo                *OneDrivePhoto   `json:"photo"`
+	Location             *GeoCoordinates  `json:"location"`
+	File                 *DriveItemFile   `json:"file"`
+	Folder               *DriveItemFolder `json:"folder"`
+	Bundle               *Bundle          `json:"bundle"`
+	Deleted              *DeletedFacet    `json:"deleted"`
+	ParentReference      *ParentReference `json:"parentReference"`
+	RemoteItem           *RemoteItem      `json:"remoteItem"`
+}
+
+// RemoteItem represents the information of a drive item which is stored in another drive,
+// for example, an item which is shared with the authenticated user.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/remoteitem?view=odsp-graph-online
+type RemoteItem struct {
+	Id              string           `json:"id"`
+	Name            string           `json:"name"`
+	Size            int64            `json:"size"`
+	WebURL          string           `json:"webUrl"`
+	File            *DriveItemFile   `json:"file"`
+	Folder          *DriveItemFolder `json:"folder"`
+	ParentReference *ParentReference `json:"parentReference"`
+}
+
+// DeletedFacet indicates that a drive item has been deleted. It is only present in the results of Delta.
+type DeletedFacet struct {
+	State string `json:"state"`
+}
+
+// OneDriveDeltaResponse represents the JSON object returned by the OneDrive API when tracking changes.
+type OneDriveDeltaResponse struct {
+	ODataContext string       `json:"@odata.context"`
+	NextLink     string       `json:"@odata.nextLink"`
+	DeltaLink    string       `json:"@odata.deltaLink"`
+	DriveItems   []*DriveItem `json:"value"`
 }
 
 // DriveItemFile represents a OneDrive drive item file info.
@@ -95,9 +133,9 @@ type MoveItemRequest struct {
 
 // ParentReference represents the information of a folder in OneDrive.
 type ParentReference struct {
-	Id      string `json:"id"`
-	Path    string `json:"path"`
-	DriveId string `json:"driveId"`
+	Id      string `json:"id,omitempty"`
+	Path    string `json:"path,omitempty"`
+	DriveId string `json:"driveId,omitempty"`
 }
 
 // MoveItemResponse represents the JSON object returned by the OneDrive API after moving an item.
@@ -119,6 +157,18 @@ type RenameItemResponse struct {
 	File Facet  `json:"file"`
 }
 
+// RestoreItemRequest represents the information needed of restoring a deleted item in OneDrive.
+type RestoreItemRequest struct {
+	ParentFolder *ParentReference `json:"parentReference,omitempty"`
+	Name         string           `json:"name,omitempty"`
+}
+
+// DeleteItemResult represents the outcome of deleting one of the items in a bulk deletion.
+type DeleteItemResult struct {
+	ItemId string
+	Err    error // nil if the item was deleted successfully.
+}
+
 // CopyItemRequest represents the information needed of copying an item in OneDrive.
 type CopyItemRequest struct {
 	Name         string          `json:"name"`
@@ -131,14 +181,27 @@ type CopyItemResponse struct {
 }
 
 // OneDriveAudio represents the audio metadata of a OneDrive drive item which is an audio.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/audio?view=graph-rest-1.0
 type OneDriveAudio struct {
-	Title       string `json:"title"`
-	Album       string `json:"album"`
-	AlbumArtist string `json:"albumArtist"`
-	Duration    int    `json:"duration"`
+	Title             string `json:"title"`
+	Album             string `json:"album"`
+	AlbumArtist       string `json:"albumArtist"`
+	Artist            string `json:"artist"`
+	Composers         string `json:"composers"`
+	Copyright         string `json:"copyright"`
+	Genre             string `json:"genre"`
+	Bitrate           int64  `json:"bitrate"`  // In kilobits per second.
+	Duration          int    `json:"duration"` // In milliseconds.
+	Disc              int    `json:"disc"`
+	DiscCount         int    `json:"discCount"`
+	Track             int    `json:"track"`
+	TrackCount        int    `json:"trackCount"`
+	Year              int    `json:"year"`
+	HasDrm            bool   `json:"hasDrm"`
+	IsVariableBitrate bool   `json:"isVariableBitrate"`
 }
 
-// OneDriveAudio represents the image metadata of a OneDrive drive item which is an image.
+// OneDriveImage represents the image metadata of a OneDrive drive item which is an image.
 type OneDriveImage struct {
 	Height float64 `json:"height"`
 	Width  float64 `json:"width"`
@@ -147,25 +210,69 @@ type OneDriveImage struct {
 // OneDrivePhoto represents the photo metadata of a OneDrive drive item which is a photo.
 // Ref https://docs.microsoft.com/en-us/graph/api/resources/photo?view=graph-rest-1.0
 type OneDrivePhoto struct {
-	CameraMake  string `json:"cameraMake"`
-	CameraModel string `json:"cameraModel"`
+	CameraMake          string    `json:"cameraMake"`
+	CameraModel         string    `json:"cameraModel"`
+	TakenDateTime       time.Time `json:"takenDateTime"`
+	ExposureNumerator   float64   `json:"exposureNumerator"`
+	ExposureDenominator float64   `json:"exposureDenominator"`
+	FNumber             float64   `json:"fNumber"`
+	FocalLength         float64   `json:"focalLength"` // In millimeters.
+	Iso                 int       `json:"iso"`
+	Orientation         int       `json:"orientation"` // The EXIF orientation, from 1 to 8.
+}
+
+// ExposureTime returns the exposure time of the photo in seconds, or 0 if it is unknown.
+func (p *OneDrivePhoto) ExposureTime() float64 {
+	if p.ExposureDenominator == 0 {
+		return 0
+	}
+
+	return p.ExposureNumerator / p.ExposureDenominator
 }
 
 // OneDriveVideo represents the video metadata of a OneDrive drive item.
 // Ref: https://docs.microsoft.com/en-us/graph/api/resources/video?view=graph-rest-1.0
 type OneDriveVideo struct {
-	Duration int     `json:"duration"`
-	Height   float64 `json:"height"`
-	Width    float64 `json:"width"`
+	Duration              int     `json:"duration"` // In milliseconds.
+	Height                float64 `json:"height"`
+	Width                 float64 `json:"width"`
+	Bitrate               int64   `json:"bitrate"` // In bits per second.
+	FourCC                string  `json:"fourCC"`  // The codec of the video.
+	FrameRate             float64 `json:"frameRate"`
+	AudioFormat           string  `json:"audioFormat"`
+	AudioChannels         int     `json:"audioChannels"`
+	AudioBitsPerSample    int     `json:"audioBitsPerSample"`
+	AudioSamplesPerSecond int     `json:"audioSamplesPerSecond"`
+}
+
+// GeoCoordinates represents the geographic location of a drive item, e.g. where a photo was taken.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/geocoordinates?view=graph-rest-1.0
+type GeoCoordinates struct {
+	Altitude  float64 `json:"altitude"` // In feet above sea level.
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // List the items of a folder in the default drive of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/driveitem?view=odsp-graph-online
 func (s *DriveItemsService) List(ctx context.Context, folderId string) (*OneDriveDriveItemsResponse, error) {
-	apiURL := "me/drive/items/" + url.PathEscape(folderId) + "/children"
+	return s.ListInDrive(ctx, "", folderId)
+}
+
+// ListInDrive lists the items of a folder in a drive which the authenticated user can access,
+// for example, a drive which contains an item shared with the user.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If folderId is empty, it means the items at the root of the drive will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListInDrive(ctx context.Context, driveId string, folderId string) (*OneDriveDriveItemsResponse, error) {
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(folderId) + "/children"
 	if folderId == "" {
-		apiURL = "me/drive/root/children"
+		apiURL = s.client.itemPathURL(driveId, "", "children")
 	}
 
 	req, err := s.client.NewRequest("GET", apiURL, nil)
@@ -186,7 +293,11 @@ func (s *DriveItemsService) List(ctx context.Context, folderId string) (*OneDriv
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get_specialfolder?view=odsp-graph-online#get-children-of-a-special-folder
 func (s *DriveItemsService) ListSpecial(ctx context.Context, folderName DriveSpecialFolder) (*OneDriveDriveItemsResponse, error) {
-	apiURL := "me/drive/special/" + url.PathEscape(folderName.toString()) + "/children"
+	if folderName.toString() == "" {
+		return nil, errors.New("Please specify which special folder to use.")
+	}
+
+	apiURL := s.client.driveURL("") + "/special/" + url.PathEscape(folderName.toString()) + "/children"
 
 	req, err := s.client.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -202,15 +313,77 @@ func (s *DriveItemsService) ListSpecial(ctx context.Context, folderName DriveSpe
 	return oneDriveResponse, nil
 }
 
+// Delta tracks the changes of the items in a folder, including its subfolders, of a drive which the authenticated user can access.
+//
+// All the pages of the changes will be retrieved. The returned DeltaLink can be used in the next call to
+// retrieve only the changes made since this call. The deleted items are returned with the Deleted facet.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If folderId is empty, it means the changes of the whole drive will be tracked.
+//
+// If deltaLink is empty, it means all the items will be returned as the initial state of the folder.
+// Otherwise, driveId and folderId will be ignored.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online
+func (s *DriveItemsService) Delta(ctx context.Context, driveId string, folderId string, deltaLink string) (*OneDriveDeltaResponse, error) {
+	apiURL := deltaLink
+	if apiURL == "" {
+		apiURL = s.client.driveURL(driveId) + "/items/" + url.PathEscape(folderId) + "/delta"
+		if folderId == "" {
+			apiURL = s.client.itemPathURL(driveId, "", "delta")
+		}
+	}
+
+	var changes *OneDriveDeltaResponse
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var oneDriveResponse *OneDriveDeltaResponse
+		err = s.client.Do(ctx, req, false, &oneDriveResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		if changes == nil {
+			changes = oneDriveResponse
+		} else {
+			changes.DriveItems = append(changes.DriveItems, oneDriveResponse.DriveItems...)
+			changes.DeltaLink = oneDriveResponse.DeltaLink
+		}
+
+		apiURL = oneDriveResponse.NextLink
+	}
+
+	changes.NextLink = ""
+
+	return changes, nil
+}
+
 // Get an item in the default drive of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
 func (s *DriveItemsService) Get(ctx context.Context, itemId string) (*DriveItem, error) {
+	return s.GetInDrive(ctx, "", itemId)
+}
+
+// GetInDrive gets an item in a drive which the authenticated user can access,
+// for example, a drive which contains an item shared with the user.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (s *DriveItemsService) GetInDrive(ctx context.Context, driveId string, itemId string) (*DriveItem, error) {
 	if itemId == "" {
 		return nil, errors.New("Please provide the Item ID of the item.")
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId)
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId)
 
 	req, err := s.client.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -234,7 +407,33 @@ func (s *DriveItemsService) GetSpecial(ctx context.Context, folderName DriveSpec
 		return nil, errors.New("Please specify which special folder to use.")
 	}
 
-	apiURL := "me/drive/special/" + url.PathEscape(folderName.toString())
+	apiURL := s.client.driveURL("") + "/special/" + url.PathEscape(folderName.toString())
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// GetByPath gets an item by its path, e.g. "Documents/Report.docx", in a drive which the authenticated user can access.
+// The path is relative to the root folder of the drive, or to the app folder if the client is returned by Client.AppFolder.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If itemPath is empty, it means the root folder will be returned.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get?view=odsp-graph-online
+func (s *DriveItemsService) GetByPath(ctx context.Context, driveId string, itemPath string) (*DriveItem, error) {
+	apiURL := s.client.itemPathURL(driveId, itemPath, "")
 
 	req, err := s.client.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -250,6 +449,32 @@ func (s *DriveItemsService) GetSpecial(ctx context.Context, folderName DriveSpec
 	return driveItem, nil
 }
 
+// ListByPath lists the items of a folder by its path in a drive which the authenticated user can access.
+// The path is relative to the root folder of the drive, or to the app folder if the client is returned by Client.AppFolder.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If folderPath is empty, it means the items in the root folder will be listed.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *DriveItemsService) ListByPath(ctx context.Context, driveId string, folderPath string) (*OneDriveDriveItemsResponse, error) {
+	apiURL := s.client.itemPathURL(driveId, folderPath, "children")
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
 // Create a new folder in a drive of the authenticated user.
 // If there is already a folder in the same OneDrive directory with the same name,
 // OneDrive will choose a new name for the folder while creating it.
@@ -258,7 +483,7 @@ func (s *DriveItemsService) GetSpecial(ctx context.Context, folderName DriveSpec
 // the authenticated user.
 //
 // If parentFolderName is empty, it means the new folder will be created at
-// the root of the default drive.
+// the root of the drive.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_post_children?view=odsp-graph-online
 func (s *DriveItemsService) CreateNewFolder(ctx context.Context, driveId string, parentFolderName string, folderName string) (*DriveItem, error) {
@@ -266,13 +491,9 @@ func (s *DriveItemsService) CreateNewFolder(ctx context.Context, driveId string,
 		return nil, errors.New("Please provide the folder name.")
 	}
 
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(parentFolderName) + "/children"
 	if parentFolderName == "" {
-		parentFolderName = "root"
-	}
-
-	apiURL := "me/drive/items/" + url.PathEscape(parentFolderName) + "/children"
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(parentFolderName) + "/children"
+		apiURL = s.client.itemPathURL(driveId, "", "children")
 	}
 
 	folderFacet := &Facet{}
@@ -297,6 +518,44 @@ func (s *DriveItemsService) CreateNewFolder(ctx context.Context, driveId string,
 	return driveItem, nil
 }
 
+// CreateFolderByPath creates a new folder in a parent folder specified by its path in a drive of the authenticated user.
+// The path is relative to the root folder of the drive, or to the app folder if the client is returned by Client.AppFolder.
+// If there is already a folder in the same OneDrive directory with the same name,
+// OneDrive will choose a new name for the folder while creating it.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If parentFolderPath is empty, it means the new folder will be created in the root folder.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_post_children?view=odsp-graph-online
+func (s *DriveItemsService) CreateFolderByPath(ctx context.Context, driveId string, parentFolderPath string, folderName string) (*DriveItem, error) {
+	if folderName == "" {
+		return nil, errors.New("Please provide the folder name.")
+	}
+
+	apiURL := s.client.itemPathURL(driveId, parentFolderPath, "children")
+
+	newFolder := &NewFolderCreationRequest{
+		FolderName:       folderName,
+		FolderFacet:      Facet{},
+		ConflictBehavior: "rename",
+	}
+
+	req, err := s.client.NewRequest("POST", apiURL, newFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
 // Delete will delete a drive item in a drive of the authenticated user.
 // The deleted item will be moved to the Recycle Bin instead of getting permanently deleted.
 //
@@ -309,12 +568,41 @@ func (s *DriveItemsService) Delete(ctx context.Context, driveId string, itemId s
 		return errors.New("Please provide the Item ID of the item to be deleted.")
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId)
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId)
+
+	req, err := s.client.NewRequest("DELETE", apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Do(ctx, req, false, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PermanentDelete will permanently delete a drive item in a drive of the authenticated user.
+// Unlike Delete, the item will NOT be moved to the Recycle Bin and thus cannot be restored later.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://learn.microsoft.com/en-us/graph/api/driveitem-permanentdelete?view=graph-rest-1.0
+func (s *DriveItemsService) PermanentDelete(ctx context.Context, driveId string, itemId string) error {
+	if itemId == "" {
+		return errors.New("Please provide the Item ID of the item to be deleted.")
+	}
+
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId) + "/permanentDelete"
+
+	req, err := s.client.NewRequest("POST", apiURL, nil)
+	if err != nil {
+		return err
 	}
 
-	_, err := s.client.NewRequest("DELETE", apiURL, nil)
+	err = s.client.Do(ctx, req, false, nil)
 	if err != nil {
 		return err
 	}
@@ -322,6 +610,74 @@ func (s *DriveItemsService) Delete(ctx context.Context, driveId string, itemId s
 	return nil
 }
 
+// DeleteMultiple will delete the given drive items in a drive of the authenticated user one by one.
+// If permanent is true, the items will be permanently deleted instead of being moved to the Recycle Bin.
+//
+// A failure in deleting one item will not stop the deletion of the remaining items. Instead, the outcome
+// of each deletion is reported in the returned results, in the same order as the given item IDs.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func (s *DriveItemsService) DeleteMultiple(ctx context.Context, driveId string, itemIds []string, permanent bool) ([]*DeleteItemResult, error) {
+	if len(itemIds) == 0 {
+		return nil, errors.New("Please provide the Item IDs of the items to be deleted.")
+	}
+
+	results := make([]*DeleteItemResult, 0, len(itemIds))
+	for _, itemId := range itemIds {
+		var err error
+		if permanent {
+			err = s.PermanentDelete(ctx, driveId, itemId)
+		} else {
+			err = s.Delete(ctx, driveId, itemId)
+		}
+
+		results = append(results, &DeleteItemResult{ItemId: itemId, Err: err})
+	}
+
+	return results, nil
+}
+
+// Restore a deleted drive item from the Recycle Bin of a drive of the authenticated user.
+//
+// If destinationParentFolderId is empty, the item will be restored to its original parent folder.
+// If newItemName is empty, the item will be restored with its original name.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://learn.microsoft.com/en-us/graph/api/driveitem-restore?view=graph-rest-1.0
+func (s *DriveItemsService) Restore(ctx context.Context, driveId string, itemId string, destinationParentFolderId string, newItemName string) (*DriveItem, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item to be restored.")
+	}
+
+	restoreItemRequest := &RestoreItemRequest{
+		Name: newItemName,
+	}
+
+	if destinationParentFolderId != "" {
+		restoreItemRequest.ParentFolder = &ParentReference{
+			Id: destinationParentFolderId,
+		}
+	}
+
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId) + "/restore"
+
+	req, err := s.client.NewRequest("POST", apiURL, restoreItemRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
 // Move a drive item to a new parent folder in a drive of the authenticated user.
 //
 // When moving an item to the root of a drive, for example, we cannot use "root"
@@ -348,10 +704,7 @@ func (s *DriveItemsService) Move(ctx context.Context, driveId string, itemId str
 		ParentFolder: *destinationParentFolder,
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId)
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
-	}
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId)
 
 	req, err := s.client.NewRequest("PATCH", apiURL, targetParentFolder)
 	if err != nil {
@@ -386,10 +739,7 @@ func (s *DriveItemsService) Rename(ctx context.Context, driveId string, itemId s
 		Name: newItemName,
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId)
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
-	}
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId)
 
 	req, err := s.client.NewRequest("PATCH", apiURL, newNameRequest)
 	if err != nil {
@@ -426,7 +776,7 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 	}
 
 	if destinationDriveId == "" {
-		reqDefaultDriveInfo, err := s.client.NewRequest("GET", "me/drive", nil)
+		reqDefaultDriveInfo, err := s.client.NewRequest("GET", s.client.driveURL(""), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -450,10 +800,7 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 		Name:         newItemName,
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/copy"
-	if sourceDriveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(sourceDriveId) + "/items/" + url.PathEscape(itemId) + "/copy"
-	}
+	apiURL := s.client.driveURL(sourceDriveId) + "/items/" + url.PathEscape(itemId) + "/copy"
 
 	req, err := s.client.NewRequest("POST", apiURL, copyItemRequest)
 	if err != nil {
@@ -469,6 +816,25 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 	return response, nil
 }
 
+// CopyAndWait copies a drive item just like Copy, but blocks until the copy job completes and then
+// returns the new item. If the copy job fails, an *AsyncJobError will be returned.
+//
+// If options is nil, the monitor URL of the copy job will be polled with the default backoff settings.
+//
+// If sourceDriveId or destinationDriveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_copy?view=odsp-graph-online
+func (s *DriveItemsService) CopyAndWait(ctx context.Context, sourceDriveId string, itemId string,
+	destinationDriveId string, destinationFolderId string, newItemName string, options *WaitOptions) (*DriveItem, error) {
+	response, err := s.Copy(ctx, sourceDriveId, itemId, destinationDriveId, destinationFolderId, newItemName)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*DriveAsyncJobService)(s).Wait(ctx, destinationDriveId, response.Location, options)
+}
+
 // UploadNewFile is to upload a file to a drive of the authenticated user.
 //
 // By default, this API will upload and then rename an item if there is an existing item
@@ -477,12 +843,40 @@ func (s *DriveItemsService) Copy(ctx context.Context, sourceDriveId string, item
 // If driveId is empty, it means the selected drive will be the default drive of
 // the authenticated user.
 //
+// If CheckQuotaBeforeUpload of the client is true, the quota of the drive is checked before the upload.
+//
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
 func (s *DriveItemsService) UploadNewFile(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string) (*DriveItem, error) {
 	if destinationParentFolderId == "" {
 		return nil, errors.New("Please provide the destination, i.e. the ID of the parent folder for this new item.")
 	}
 
+	return s.uploadNewFile(ctx, driveId, localFilePath, func(fileName string) string {
+		return s.client.driveURL(driveId) + "/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content"
+	})
+}
+
+// UploadNewFileByPath uploads a new file to a folder specified by its path in a drive of the authenticated user.
+// The path is relative to the root folder of the drive, or to the app folder if the client is returned by Client.AppFolder.
+// The folder will be created if it does not exist.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If destinationFolderPath is empty, it means the file will be uploaded to the root folder.
+//
+// If CheckQuotaBeforeUpload of the client is true, the quota of the drive is checked before the upload.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-upload-a-new-file
+func (s *DriveItemsService) UploadNewFileByPath(ctx context.Context, driveId string, destinationFolderPath string, localFilePath string) (*DriveItem, error) {
+	return s.uploadNewFile(ctx, driveId, localFilePath, func(fileName string) string {
+		return s.client.itemPathURL(driveId, strings.Trim(destinationFolderPath, "/")+"/"+fileName, "content")
+	})
+}
+
+// uploadNewFile uploads a local file whose size is less than or equal to 4MB to the API URL returned
+// by contentURL for the name of the file.
+func (s *DriveItemsService) uploadNewFile(ctx context.Context, driveId string, localFilePath string, contentURL func(fileName string) string) (*DriveItem, error) {
 	if localFilePath == "" {
 		return nil, errors.New("Please provide the path to the file on local.")
 	}
@@ -508,12 +902,14 @@ func (s *DriveItemsService) UploadNewFile(ctx context.Context, driveId string, d
 		return nil, errors.New("Only file with size less than or equal to 4MB is allowed to be uploaded here.")
 	}
 
+	err = s.checkQuotaBeforeUpload(ctx, driveId, fileSize)
+	if err != nil {
+		return nil, err
+	}
+
 	fileName := fileInfo.Name()
 
-	apiURL := "me/drive/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content?@microsoft.graph.conflictBehavior=rename"
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(destinationParentFolderId) + ":/" + url.PathEscape(fileName) + ":/content?@microsoft.graph.conflictBehavior=rename"
-	}
+	apiURL := contentURL(fileName) + "?@microsoft.graph.conflictBehavior=rename"
 
 	buffer := make([]byte, fileSize)
 	file.Read(buffer)
@@ -540,6 +936,8 @@ func (s *DriveItemsService) UploadNewFile(ctx context.Context, driveId string, d
 // If driveId is empty, it means the selected drive will be the default drive of
 // the authenticated user.
 //
+// If CheckQuotaBeforeUpload of the client is true, the quota of the drive is checked before the upload.
+//
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online#http-request-to-replace-an-existing-item
 func (s *DriveItemsService) UploadToReplaceFile(ctx context.Context, driveId string, localFilePath string, itemId string) (*DriveItem, error) {
 	if localFilePath == "" {
@@ -571,10 +969,7 @@ func (s *DriveItemsService) UploadToReplaceFile(ctx context.Context, driveId str
 		return nil, errors.New("Only file with size less than or equal to 4MB is allowed to be uploaded here.")
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/content"
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId) + "/content"
-	}
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId) + "/content"
 
 	buffer := make([]byte, fileSize)
 	file.Read(buffer)
@@ -596,6 +991,12 @@ func (s *DriveItemsService) UploadToReplaceFile(ctx context.Context, driveId str
 		return nil, fmt.Errorf("It's prohibited to replace a file with MIME Type %q which is not the same type as the uploaded file with MEME Type %q.", targetDriveItem.File.MIMEType, fileType.MIME.Value)
 	}
 
+	// The replaced content is kept in the version history, hence the whole file counts towards the quota.
+	err = s.checkQuotaBeforeUpload(ctx, driveId, fileSize)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.NewFileUploadRequest(apiURL, fileType.MIME.Value, fileReader)
 	if err != nil {
 		return nil, err
@@ -610,6 +1011,16 @@ func (s *DriveItemsService) UploadToReplaceFile(ctx context.Context, driveId str
 	return response, nil
 }
 
+// checkQuotaBeforeUpload checks whether the drive has enough remaining space for a file of the given
+// size if CheckQuotaBeforeUpload of the client is true.
+func (s *DriveItemsService) checkQuotaBeforeUpload(ctx context.Context, driveId string, size int64) error {
+	if !s.client.CheckQuotaBeforeUpload {
+		return nil
+	}
+
+	return (*DrivesService)(s).CheckQuota(ctx, driveId, size)
+}
+
 // UploadNewFileLarge is to upload a large file (> 4mb) to a drive of the authenticated user.
 //
 // This might take a long time, please consider using a new goroutine.
@@ -620,6 +1031,8 @@ func (s *DriveItemsService) UploadToReplaceFile(ctx context.Context, driveId str
 // If driveId is empty, it means the selected drive will be the default drive of
 // the authenticated user.
 //
+// If CheckQuotaBeforeUpload of the client is true, the quota of the drive is checked before the upload.
+//
 // The recommended splitting size is 5-10 MiB, depending on your internet connection.
 // Per Microsoft API, the size per split MUST BE a multiple of 320 KiB (320 * 1024)
 //
@@ -667,14 +1080,15 @@ func (s *DriveItemsService) UploadNewFileLarge(ctx context.Context, driveId stri
 		return nil, errors.New("Size per split should be a multiple of 320 KiB (327,680 bytes)")
 	}
 
-	fileName := fileInfo.Name()
+	// Reject the upload before creating the upload session if the drive does not have enough space for the file.
+	err = s.checkQuotaBeforeUpload(ctx, driveId, fileSize)
+	if err != nil {
+		return nil, err
+	}
 
-	apiURL := fmt.Sprintf("me/drive/items/%s:/%s:/createUploadSession", url.PathEscape(destinationParentFolderId), fileName)
+	fileName := fileInfo.Name()
 
-	//Did not find an official API for designated drive, I'll just keep the api in the same format.
-	if driveId != "" {
-		apiURL = fmt.Sprintf("me/drives/%s/items/%s:/%s:/createUploadSession", url.PathEscape(driveId), url.PathEscape(destinationParentFolderId), fileName)
-	}
+	apiURL := fmt.Sprintf("%s/items/%s:/%s:/createUploadSession", s.client.driveURL(driveId), url.PathEscape(destinationParentFolderId), fileName)
 
 	sessionCreationRequestInside := NewUploadSessionCreationRequest{
 		//select from: rename | fail | replace
@@ -755,32 +1169,185 @@ func (s *DriveItemsService) UploadNewFileLarge(ctx context.Context, driveId stri
 // DownloadItem downloads the given item from OneDrive
 func (s *DriveItemsService) DownloadItem(ctx context.Context, item *DriveItem) ([]byte, error) {
 	if item.DownloadURL == "" {
+		driveId := ""
+		if item.ParentReference != nil {
+			driveId = item.ParentReference.DriveId
+		}
+
 		var err error
-		item, err = s.Get(ctx, item.Id)
+		item, err = s.GetInDrive(ctx, driveId, item.Id)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	resp, err := s.client.client.Get(item.DownloadURL)
+	content, err := openDownloadURL(ctx, item.DownloadURL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	defer content.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	return io.ReadAll(content)
+}
+
+// DownloadStream downloads the content of an item in a drive which the authenticated user can access.
+// The content is streamed through the returned reader, which must be closed by the caller.
+//
+// A fresh download URL of the item is always retrieved before the download, so that the stream
+// can be opened at any time, even long after the item has been listed.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *DriveItemsService) DownloadStream(ctx context.Context, driveId string, itemId string) (io.ReadCloser, error) {
+	item, err := s.GetInDrive(ctx, driveId, itemId)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(body, &errResp); err != nil {
+
+	if item.DownloadURL == "" {
+		return nil, fmt.Errorf("The item %q cannot be downloaded.", item.Name)
+	}
+
+	return openDownloadURL(ctx, item.DownloadURL)
+}
+
+// DownloadItemAs downloads the given file from OneDrive after converting its content to the given format,
+// e.g. a PDF rendition of an Office document or a JPG rendition of a HEIC photo. The converted content is
+// streamed through the returned reader, which must be closed by the caller.
+//
+// If the file cannot be converted to the format according to its MIME type, an error will be returned
+// without sending the download request.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content_format?view=odsp-graph-online
+func (s *DriveItemsService) DownloadItemAs(ctx context.Context, item *DriveItem, format DownloadFormat) (io.ReadCloser, error) {
+	if item == nil || item.Id == "" {
+		return nil, errors.New("Please provide the item to be downloaded.")
+	}
+
+	if format.toString() == "" {
+		return nil, errors.New("Please specify which format to convert the item to.")
+	}
+
+	driveId := ""
+	if item.ParentReference != nil {
+		driveId = item.ParentReference.DriveId
+	}
+
+	if item.File == nil && item.Folder == nil {
+		var err error
+		item, err = s.GetInDrive(ctx, driveId, item.Id)
+		if err != nil {
 			return nil, err
 		}
-		if errResp.Error == nil {
-			return nil, fmt.Errorf("%s: %s", resp.Status, string(body))
-		}
-		return nil, errors.New(errResp.Error.Code + ": " + errResp.Error.Message)
 	}
-	return body, nil
+
+	if item.File == nil {
+		return nil, fmt.Errorf("The item %q is not a file and cannot be converted to %s.", item.Name, format.toString())
+	}
+
+	if !format.canConvertFrom(item.File.MIMEType) {
+		return nil, fmt.Errorf("The file %q of MIME type %q cannot be converted to %s.", item.Name, item.File.MIMEType, format.toString())
+	}
+
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(item.Id) + "/content?format=" + format.toString()
+
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.openContent(ctx, req)
+}
+
+// download sends the given request to download the content of an item and returns the downloaded content.
+func (c *Client) download(ctx context.Context, req *http.Request) ([]byte, error) {
+	content, err := c.openContent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	return io.ReadAll(content)
+}
+
+// openContent sends the given request for the content of an item and returns the stream of the content,
+// which must be closed by the caller.
+//
+// The OneDrive API responds with a redirect to a pre-authenticated URL of the content. The redirect is
+// not followed by the authenticated HTTP client, so that the access token will not be sent to the host
+// of the content.
+func (c *Client) openContent(ctx context.Context, req *http.Request) (io.ReadCloser, error) {
+	httpClient := *c.client
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	setClientRequestId(ctx, req)
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	recordResponse(ctx, resp)
+
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+
+	if resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusSeeOther && resp.StatusCode != http.StatusTemporaryRedirect {
+		defer resp.Body.Close()
+		return nil, downloadError(resp)
+	}
+
+	resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	return openDownloadURL(ctx, location.String())
+}
+
+// openDownloadURL returns the stream of the content at the given pre-authenticated download URL, which must be
+// closed by the caller. The access token is not sent along with the request to the host of the content.
+func openDownloadURL(ctx context.Context, downloadURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", downloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	setClientRequestId(ctx, req)
+
+	resp, err := (&http.Client{}).Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	recordResponse(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, downloadError(resp)
+	}
+
+	return resp.Body, nil
+}
+
+// downloadError returns the error of a failed download from the response.
+func downloadError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return err
+	}
+	if errResp.Error == nil {
+		return fmt.Errorf("%s: %s", resp.Status, string(body))
+	}
+	return errResp.Error
 }
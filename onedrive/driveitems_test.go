@@ -11,8 +11,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDriveItemsService_ListRoot_authenticatedUser(t *testing.T) {
@@ -98,3 +101,673 @@ func TestDriveItemsService_Get_authenticatedUser(t *testing.T) {
 	}
 
 }
+
+func TestDriveItemsService_Delete_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	isDeleteRequestSent := false
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+
+		isDeleteRequestSent = true
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	err := client.DriveItems.Delete(ctx, "", "1")
+	if err != nil {
+		t.Errorf("DriveItems.Delete returned error: %v", err)
+	}
+
+	if !isDeleteRequestSent {
+		t.Errorf("DriveItems.Delete did not send the DELETE request")
+	}
+}
+
+func TestDriveItemsService_PermanentDelete_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	isDeleteRequestSent := false
+	mux.HandleFunc("/drives/drive1/items/1/permanentDelete", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		isDeleteRequestSent = true
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	err := client.DriveItems.PermanentDelete(ctx, "drive1", "1")
+	if err != nil {
+		t.Errorf("DriveItems.PermanentDelete returned error: %v", err)
+	}
+
+	if !isDeleteRequestSent {
+		t.Errorf("DriveItems.PermanentDelete did not send the permanentDelete request")
+	}
+}
+
+func TestDriveItemsService_DeleteMultiple_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/me/drive/items/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+
+	ctx := context.Background()
+	results, err := client.DriveItems.DeleteMultiple(ctx, "", []string{"1", "2"}, false)
+	if err != nil {
+		t.Fatalf("DriveItems.DeleteMultiple returned error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("DriveItems.DeleteMultiple returned %v results, want 2", len(results))
+	}
+
+	if results[0].ItemId != "1" || results[0].Err != nil {
+		t.Errorf("DriveItems.DeleteMultiple returned %+v for the first item, want a successful deletion", results[0])
+	}
+
+	if results[1].ItemId != "2" || results[1].Err == nil {
+		t.Errorf("DriveItems.DeleteMultiple returned %+v for the second item, want a failed deletion", results[1])
+	}
+}
+
+func TestDriveItemsService_Restore_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/restore", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var restoreItemRequest *RestoreItemRequest
+		json.NewDecoder(r.Body).Decode(&restoreItemRequest)
+
+		wantRestoreItemRequest := &RestoreItemRequest{
+			ParentFolder: &ParentReference{Id: "2"},
+			Name:         "Restored.txt",
+		}
+		if !reflect.DeepEqual(restoreItemRequest, wantRestoreItemRequest) {
+			t.Errorf("Request body = %+v, want %+v", restoreItemRequest, wantRestoreItemRequest)
+		}
+
+		jsonData := getTestDataFromFile(t, "fake_driveItem.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.Restore(ctx, "", "1", "2", "Restored.txt")
+	if err != nil {
+		t.Errorf("DriveItems.Restore returned error: %v", err)
+	}
+
+	var wantDriveItem *DriveItem
+	json.Unmarshal(getTestDataFromFile(t, "fake_driveItem.json"), &wantDriveItem)
+
+	if !reflect.DeepEqual(gotDriveItem, wantDriveItem) {
+		t.Errorf("DriveItems.Restore returned %+v, want %+v", gotDriveItem, wantDriveItem)
+	}
+}
+
+func TestDriveItemsService_UploadNewFileLarge_insufficientQuota(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	client.CheckQuotaBeforeUpload = true
+
+	mux.HandleFunc("/drives/drive1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "drive1", "quota": {"used": 5368709120, "remaining": 1024, "total": 5368710144, "state": "critical"}}`)
+	})
+
+	mux.HandleFunc("/drives/drive1/items/folder1:/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v after the quota check has failed", r.URL)
+	})
+
+	localFile, err := ioutil.TempFile("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(localFile.Name())
+
+	if _, err := localFile.Write(make([]byte, 2048)); err != nil {
+		t.Fatal(err)
+	}
+	localFile.Close()
+
+	ctx := context.Background()
+	_, err = client.DriveItems.UploadNewFileLarge(ctx, "drive1", "folder1", localFile.Name(), 320*1024)
+
+	quotaErr, ok := err.(*InsufficientQuotaError)
+	if !ok {
+		t.Fatalf("DriveItems.UploadNewFileLarge returned %v, want an *InsufficientQuotaError", err)
+	}
+
+	if quotaErr.RequiredSize != 2048 || quotaErr.Remaining != 1024 {
+		t.Errorf("DriveItems.UploadNewFileLarge returned %+v, want 2048 bytes required and 1024 bytes remaining", quotaErr)
+	}
+}
+
+func TestDriveItemsService_Upload_insufficientQuota(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	client.CheckQuotaBeforeUpload = true
+
+	mux.HandleFunc("/drives/drive1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "drive1", "quota": {"used": 5368709120, "remaining": 1024, "total": 5368710144, "state": "critical"}}`)
+	})
+
+	mux.HandleFunc("/me/drive/items/file1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "file1", "name": "data.bin", "file": {}}`)
+	})
+
+	mux.HandleFunc("/drives/drive1/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v after the quota check has failed", r.URL)
+	})
+
+	localFile, err := ioutil.TempFile("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(localFile.Name())
+
+	if _, err := localFile.Write(make([]byte, 2048)); err != nil {
+		t.Fatal(err)
+	}
+	localFile.Close()
+
+	ctx := context.Background()
+	uploads := map[string]func() (*DriveItem, error){
+		"UploadNewFile": func() (*DriveItem, error) {
+			return client.DriveItems.UploadNewFile(ctx, "drive1", "folder1", localFile.Name())
+		},
+		"UploadNewFileByPath": func() (*DriveItem, error) {
+			return client.DriveItems.UploadNewFileByPath(ctx, "drive1", "Documents", localFile.Name())
+		},
+		"UploadToReplaceFile": func() (*DriveItem, error) {
+			return client.DriveItems.UploadToReplaceFile(ctx, "drive1", localFile.Name(), "file1")
+		},
+	}
+
+	for name, upload := range uploads {
+		_, err := upload()
+
+		quotaErr, ok := err.(*InsufficientQuotaError)
+		if !ok {
+			t.Errorf("DriveItems.%s returned %v, want an *InsufficientQuotaError", name, err)
+			continue
+		}
+
+		if quotaErr.RequiredSize != 2048 || quotaErr.Remaining != 1024 {
+			t.Errorf("DriveItems.%s returned %+v, want 2048 bytes required and 1024 bytes remaining", name, quotaErr)
+		}
+	}
+}
+
+func TestDriveItemsService_Special_invalidFolder(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	ctx := context.Background()
+	for _, folderName := range []DriveSpecialFolder{-1, Recordings + 1} {
+		if _, err := client.DriveItems.GetSpecial(ctx, folderName); err == nil {
+			t.Errorf("DriveItems.GetSpecial(%d) returned no error", folderName)
+		}
+
+		if _, err := client.DriveItems.ListSpecial(ctx, folderName); err == nil {
+			t.Errorf("DriveItems.ListSpecial(%d) returned no error", folderName)
+		}
+	}
+}
+
+func TestDriveItemsService_GetSpecial_recordings(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/special/recordings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "recordings1", "name": "Recordings", "folder": {"childCount": 3}}`)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.GetSpecial(ctx, Recordings)
+	if err != nil {
+		t.Fatalf("DriveItems.GetSpecial returned error: %v", err)
+	}
+
+	if gotDriveItem.Id != "recordings1" {
+		t.Errorf("DriveItems.GetSpecial returned %+v, want the recordings folder", gotDriveItem)
+	}
+}
+
+func TestDriveItemsService_GetByPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/drive1/root:/My Documents/Report #1.docx", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if got, want := r.URL.EscapedPath(), "/drives/drive1/root:/My%20Documents/Report%20%231.docx"; got != want {
+			t.Errorf("Request path = %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"id": "1", "name": "Report #1.docx"}`)
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.DriveItems.GetByPath(ctx, "drive1", "/My Documents/Report #1.docx")
+	if err != nil {
+		t.Fatalf("DriveItems.GetByPath returned error: %v", err)
+	}
+
+	if gotDriveItem.Name != "Report #1.docx" {
+		t.Errorf("DriveItems.GetByPath returned %+v, want Report #1.docx", gotDriveItem)
+	}
+}
+
+func TestClient_AppFolder(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	var requestedPaths []string
+	recordRequest := func(response string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requestedPaths = append(requestedPaths, r.Method+" "+r.URL.Path)
+
+			fmt.Fprint(w, response)
+		}
+	}
+
+	mux.HandleFunc("/me/drive/special/approot:/settings/config.json", recordRequest(`{"id": "config"}`))
+	mux.HandleFunc("/me/drive/special/approot:/settings:/children", recordRequest(`{"value": []}`))
+	mux.HandleFunc("/me/drive/special/approot/children", recordRequest(`{"id": "settings", "value": []}`))
+	mux.HandleFunc("/me/drive/special/approot:/settings/upload.txt:/content", recordRequest(`{"id": "upload"}`))
+
+	localDir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(localDir)
+
+	uploadFilePath := filepath.Join(localDir, "upload.txt")
+	if err := ioutil.WriteFile(uploadFilePath, []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	appFolderClient := client.AppFolder()
+
+	ctx := context.Background()
+	if _, err := appFolderClient.DriveItems.GetByPath(ctx, "", "settings/config.json"); err != nil {
+		t.Errorf("DriveItems.GetByPath returned error: %v", err)
+	}
+
+	if _, err := appFolderClient.DriveItems.ListByPath(ctx, "", "settings"); err != nil {
+		t.Errorf("DriveItems.ListByPath returned error: %v", err)
+	}
+
+	if _, err := appFolderClient.DriveItems.List(ctx, ""); err != nil {
+		t.Errorf("DriveItems.List returned error: %v", err)
+	}
+
+	if _, err := appFolderClient.DriveItems.CreateFolderByPath(ctx, "", "", "settings"); err != nil {
+		t.Errorf("DriveItems.CreateFolderByPath returned error: %v", err)
+	}
+
+	if _, err := appFolderClient.DriveItems.UploadNewFileByPath(ctx, "", "/settings/", uploadFilePath); err != nil {
+		t.Errorf("DriveItems.UploadNewFileByPath returned error: %v", err)
+	}
+
+	wantPaths := []string{
+		"GET /me/drive/special/approot:/settings/config.json",
+		"GET /me/drive/special/approot:/settings:/children",
+		"GET /me/drive/special/approot/children",
+		"POST /me/drive/special/approot/children",
+		"PUT /me/drive/special/approot:/settings/upload.txt:/content",
+	}
+	if !reflect.DeepEqual(requestedPaths, wantPaths) {
+		t.Errorf("The app folder client requested %v, want %v", requestedPaths, wantPaths)
+	}
+
+	if got := client.itemPathURL("", "settings", ""); got != "me/drive/root:/settings" {
+		t.Errorf("itemPathURL of the original client = %q, want %q", got, "me/drive/root:/settings")
+	}
+}
+
+// authorizingTransport adds an access token to all the requests, just like the transport of an oauth2 HTTP client.
+type authorizingTransport struct{}
+
+func (authorizingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer token")
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestDriveItemsService_DownloadItem(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	client.client = &http.Client{Transport: authorizingTransport{}}
+
+	mux.HandleFunc("/drives/drive1/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "Bearer token")
+
+		fmt.Fprintf(w, `{"id": "1", "name": "Song.mp3", "@microsoft.graph.downloadUrl": "%s/download/1"}`, serverURL+baseURLPath)
+	})
+
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "")
+
+		fmt.Fprint(w, "Hello, World!")
+	})
+
+	ctx := context.Background()
+	gotContent, err := client.DriveItems.DownloadItem(ctx, &DriveItem{Id: "1", ParentReference: &ParentReference{DriveId: "drive1"}})
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadItem returned error: %v", err)
+	}
+
+	if string(gotContent) != "Hello, World!" {
+		t.Errorf("DriveItems.DownloadItem returned %q, want %q", gotContent, "Hello, World!")
+	}
+}
+
+func TestDriveItemsService_DownloadItemAs(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	client.client = &http.Client{Transport: authorizingTransport{}}
+
+	mux.HandleFunc("/drives/drive1/items/1/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "Bearer token")
+		testFormValues(t, r, map[string]string{"format": "pdf"})
+
+		http.Redirect(w, r, serverURL+baseURLPath+"/converted/1.pdf", http.StatusFound)
+	})
+
+	mux.HandleFunc("/converted/1.pdf", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Authorization", "")
+
+		fmt.Fprint(w, "%PDF-1.7")
+	})
+
+	item := &DriveItem{
+		Id:              "1",
+		Name:            "Report.docx",
+		File:            &DriveItemFile{MIMEType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		ParentReference: &ParentReference{DriveId: "drive1"},
+	}
+
+	ctx := context.Background()
+	content, err := client.DriveItems.DownloadItemAs(ctx, item, PDFFormat)
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadItemAs returned error: %v", err)
+	}
+	defer content.Close()
+
+	gotContent, err := ioutil.ReadAll(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(gotContent) != "%PDF-1.7" {
+		t.Errorf("DriveItems.DownloadItemAs returned %q, want the converted content", gotContent)
+	}
+}
+
+func TestDriveItemsService_DownloadItemAs_unsupportedType(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v for an unsupported conversion", r.URL)
+	})
+
+	testCases := []struct {
+		item   *DriveItem
+		format DownloadFormat
+	}{
+		{item: &DriveItem{Id: "1", Name: "Song.mp3", File: &DriveItemFile{MIMEType: "audio/mpeg"}}, format: PDFFormat},
+		{item: &DriveItem{Id: "2", Name: "Photo.heic", File: &DriveItemFile{MIMEType: "image/heic"}}, format: HTMLFormat},
+		{item: &DriveItem{Id: "3", Name: "Archive.zip", File: &DriveItemFile{MIMEType: "application/zip"}}, format: JPGFormat},
+		{item: &DriveItem{Id: "4", Name: "Music", Folder: &DriveItemFolder{}}, format: PDFFormat},
+		{item: &DriveItem{Id: "5", Name: "Report.docx", File: &DriveItemFile{MIMEType: "application/msword"}}, format: DownloadFormat(10)},
+	}
+
+	ctx := context.Background()
+	for _, testCase := range testCases {
+		if _, err := client.DriveItems.DownloadItemAs(ctx, testCase.item, testCase.format); err == nil {
+			t.Errorf("DriveItems.DownloadItemAs(%q, %d) returned no error", testCase.item.Name, testCase.format)
+		}
+	}
+}
+
+func TestDownloadFormat_canConvertFrom(t *testing.T) {
+	testCases := []struct {
+		format   DownloadFormat
+		mimeType string
+		want     bool
+	}{
+		{PDFFormat, "application/vnd.openxmlformats-officedocument.presentationml.presentation", true},
+		{PDFFormat, "application/vnd.ms-excel.sheet.macroEnabled.12", true},
+		{PDFFormat, "text/html; charset=utf-8", true},
+		{PDFFormat, "image/jpeg", false},
+		{JPGFormat, "image/heic", true},
+		{JPGFormat, "application/pdf", true},
+		{JPGFormat, "application/zip", false},
+		{GLBFormat, "model/obj", true},
+		{GLBFormat, "", true},
+		{GLBFormat, "application/octet-stream", true},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.format.canConvertFrom(testCase.mimeType); got != testCase.want {
+			t.Errorf("%s canConvertFrom(%q) = %v, want %v", testCase.format.toString(), testCase.mimeType, got, testCase.want)
+		}
+	}
+}
+
+func TestDriveItemsService_List_mediaFacets(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/music/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_driveItems_media.json")))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DriveItems.List(ctx, "music")
+	if err != nil {
+		t.Fatalf("DriveItems.List returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.DriveItems) != 3 {
+		t.Fatalf("DriveItems.List returned %d items, want 3", len(gotOneDriveResponse.DriveItems))
+	}
+
+	wantAudio := &OneDriveAudio{
+		Title:             "Bohemian Rhapsody",
+		Album:             "A Night at the Opera",
+		AlbumArtist:       "Queen",
+		Artist:            "Queen",
+		Composers:         "Freddie Mercury",
+		Copyright:         "1975 EMI",
+		Genre:             "Rock",
+		Bitrate:           1011,
+		Duration:          354320,
+		Disc:              1,
+		DiscCount:         1,
+		Track:             11,
+		TrackCount:        12,
+		Year:              1975,
+		IsVariableBitrate: true,
+	}
+	if gotAudio := gotOneDriveResponse.DriveItems[0].Audio; !reflect.DeepEqual(gotAudio, wantAudio) {
+		t.Errorf("DriveItems.List returned audio %+v, want %+v", gotAudio, wantAudio)
+	}
+
+	wantPhoto := &OneDrivePhoto{
+		CameraMake:          "Apple",
+		CameraModel:         "iPhone 11 Pro",
+		TakenDateTime:       time.Date(2020, 7, 4, 18, 30, 15, 0, time.UTC),
+		ExposureNumerator:   1,
+		ExposureDenominator: 120,
+		FNumber:             1.8,
+		FocalLength:         4.25,
+		Iso:                 32,
+		Orientation:         6,
+	}
+	gotPhoto := gotOneDriveResponse.DriveItems[1].Photo
+	if !reflect.DeepEqual(gotPhoto, wantPhoto) {
+		t.Errorf("DriveItems.List returned photo %+v, want %+v", gotPhoto, wantPhoto)
+	}
+
+	if got := gotPhoto.ExposureTime(); got != 1.0/120 {
+		t.Errorf("OneDrivePhoto.ExposureTime returned %v, want %v", got, 1.0/120)
+	}
+
+	wantLocation := &GeoCoordinates{Altitude: 42.5, Latitude: 1.2838, Longitude: 103.8591}
+	if gotLocation := gotOneDriveResponse.DriveItems[1].Location; !reflect.DeepEqual(gotLocation, wantLocation) {
+		t.Errorf("DriveItems.List returned location %+v, want %+v", gotLocation, wantLocation)
+	}
+
+	wantVideo := &OneDriveVideo{
+		Duration:              61750,
+		Height:                1080,
+		Width:                 1920,
+		Bitrate:               16000000,
+		FourCC:                "H264",
+		FrameRate:             29.97,
+		AudioFormat:           "AAC",
+		AudioChannels:         2,
+		AudioBitsPerSample:    16,
+		AudioSamplesPerSecond: 48000,
+	}
+	if gotVideo := gotOneDriveResponse.DriveItems[2].Video; !reflect.DeepEqual(gotVideo, wantVideo) {
+		t.Errorf("DriveItems.List returned video %+v, want %+v", gotVideo, wantVideo)
+	}
+}
+
+func TestDriveItemsService_Delta(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/drive1/items/folder1/delta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.FormValue("token") {
+		case "":
+			fmt.Fprintf(w, `{"@odata.nextLink": "%s/drives/drive1/items/folder1/delta?token=page2", "value": [{"id": "1"}]}`, serverURL+baseURLPath)
+		case "page2":
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/drives/drive1/items/folder1/delta?token=latest", "value": [{"id": "2", "deleted": {"state": "deleted"}}]}`, serverURL+baseURLPath)
+		case "latest":
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/drives/drive1/items/folder1/delta?token=latest2", "value": []}`, serverURL+baseURLPath)
+		}
+	})
+
+	ctx := context.Background()
+	gotChanges, err := client.DriveItems.Delta(ctx, "drive1", "folder1", "")
+	if err != nil {
+		t.Fatalf("DriveItems.Delta returned error: %v", err)
+	}
+
+	wantChanges := &OneDriveDeltaResponse{
+		DeltaLink: serverURL + baseURLPath + "/drives/drive1/items/folder1/delta?token=latest",
+		DriveItems: []*DriveItem{
+			{Id: "1"},
+			{Id: "2", Deleted: &DeletedFacet{State: "deleted"}},
+		},
+	}
+	if !reflect.DeepEqual(gotChanges, wantChanges) {
+		t.Errorf("DriveItems.Delta returned %+v, want %+v", gotChanges, wantChanges)
+	}
+
+	gotChanges, err = client.DriveItems.Delta(ctx, "", "", gotChanges.DeltaLink)
+	if err != nil {
+		t.Fatalf("DriveItems.Delta returned error: %v", err)
+	}
+
+	if len(gotChanges.DriveItems) != 0 || !strings.HasSuffix(gotChanges.DeltaLink, "token=latest2") {
+		t.Errorf("DriveItems.Delta returned %+v, want no changes and a new delta link", gotChanges)
+	}
+}
+
+func TestDriveItemsService_DownloadStream(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "1", "name": "Song.mp3", "@microsoft.graph.downloadUrl": "%s/download/1"}`, serverURL+baseURLPath)
+	})
+
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ID3")
+	})
+
+	mux.HandleFunc("/me/drive/items/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "2", "name": "Gone.mp3", "@microsoft.graph.downloadUrl": "%s/download/2"}`, serverURL+baseURLPath)
+	})
+
+	mux.HandleFunc("/download/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+
+	ctx := context.Background()
+	content, err := client.DriveItems.DownloadStream(ctx, "", "1")
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadStream returned error: %v", err)
+	}
+	defer content.Close()
+
+	gotContent, _ := ioutil.ReadAll(content)
+	if string(gotContent) != "ID3" {
+		t.Errorf("DriveItems.DownloadStream returned %q, want the content of the item", gotContent)
+	}
+
+	if _, err := client.DriveItems.DownloadStream(ctx, "", "2"); err == nil || !strings.Contains(err.Error(), "itemNotFound") {
+		t.Errorf("DriveItems.DownloadStream returned %v, want the itemNotFound error", err)
+	}
+}
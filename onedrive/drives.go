@@ -6,7 +6,10 @@ package onedrive
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"time"
 )
 
 // DrivesService handles communication with the drives related methods of the OneDrive API.
@@ -20,21 +23,82 @@ type OneDriveDrivesResponse struct {
 	Drives       []*Drive `json:"value"`
 }
 
+// Possible values of the state of a drive quota.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/quota?view=odsp-graph-online#properties
+const (
+	QuotaStateNormal   = "normal"   // The drive has plenty of remaining space.
+	QuotaStateNearing  = "nearing"  // The drive has less than 10% of the total space remaining.
+	QuotaStateCritical = "critical" // The drive has less than 1% of the total space remaining.
+	QuotaStateExceeded = "exceeded" // The drive is full and no more items can be uploaded to it.
+)
+
 // Drive represents a OneDrive drive.
 type Drive struct {
-	Id        string      `json:"id"`
-	DriveType string      `json:"driveType"`
-	Owner     *Owner      `json:"owner"`
-	Quota     *DriveQuota `json:"quota"`
+	Id                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	Description          string         `json:"description"`
+	WebURL               string         `json:"webUrl"`
+	DriveType            string         `json:"driveType"`
+	CreatedDateTime      time.Time      `json:"createdDateTime"`
+	LastModifiedDateTime time.Time      `json:"lastModifiedDateTime"`
+	CreatedBy            *IdentitySet   `json:"createdBy,omitempty"`
+	LastModifiedBy       *IdentitySet   `json:"lastModifiedBy,omitempty"`
+	Owner                *Owner         `json:"owner"`
+	Quota                *DriveQuota    `json:"quota"`
+	SharePointIds        *SharePointIds `json:"sharePointIds,omitempty"`
+	System               *SystemFacet   `json:"system,omitempty"` // Only present when the drive is managed by the system.
 }
 
-// DriveQuota represents the usage quota of a drive.
+// DriveQuota represents the usage quota of a drive. All the sizes are in bytes.
 type DriveQuota struct {
-	Used      int    `json:"used"`
-	Deleted   int    `json:"deleted"`
-	Remaining int    `json:"remaining"`
-	Total     int    `json:"total"`
-	State     string `json:"state"`
+	Used                   int64                   `json:"used"`
+	Deleted                int64                   `json:"deleted"`
+	Remaining              int64                   `json:"remaining"`
+	Total                  int64                   `json:"total"`
+	State                  string                  `json:"state"` // One of the QuotaState constants.
+	StoragePlanInformation *StoragePlanInformation `json:"storagePlanInformation,omitempty"`
+}
+
+// StoragePlanInformation represents the storage plan of the owner of a drive.
+type StoragePlanInformation struct {
+	UpgradeAvailable bool `json:"upgradeAvailable"`
+}
+
+// SharePointIds represents the SharePoint identifiers of a drive or an item stored in SharePoint.
+type SharePointIds struct {
+	ListId           string `json:"listId"`
+	ListItemId       string `json:"listItemId"`
+	ListItemUniqueId string `json:"listItemUniqueId"`
+	SiteId           string `json:"siteId"`
+	SiteURL          string `json:"siteUrl"`
+	TenantId         string `json:"tenantId"`
+	WebId            string `json:"webId"`
+}
+
+// SystemFacet indicates that a drive or an item is managed by the system.
+type SystemFacet struct{}
+
+// InsufficientQuotaError is returned when a drive does not have enough remaining space for an upload.
+type InsufficientQuotaError struct {
+	DriveId      string
+	RequiredSize int64
+	Remaining    int64
+	State        string
+}
+
+func (e *InsufficientQuotaError) Error() string {
+	return fmt.Sprintf("The drive %q does not have enough space for %d bytes: %d bytes remaining (quota state: %s)",
+		e.DriveId, e.RequiredSize, e.Remaining, e.State)
+}
+
+// HasSpaceFor reports whether the drive has enough remaining space for the given number of bytes.
+func (q *DriveQuota) HasSpaceFor(size int64) bool {
+	if q.State == QuotaStateExceeded {
+		return false
+	}
+
+	return q.Remaining >= size
 }
 
 // Get a specified drive of the authenticated user.
@@ -44,10 +108,7 @@ type DriveQuota struct {
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online
 func (s *DrivesService) Get(ctx context.Context, driveId string) (*Drive, error) {
-	apiURL := "me/drives/" + url.PathEscape(driveId)
-	if driveId == "" {
-		apiURL = "me/drive"
-	}
+	apiURL := s.client.driveURL(driveId)
 
 	req, err := s.client.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -63,11 +124,90 @@ func (s *DrivesService) Get(ctx context.Context, driveId string) (*Drive, error)
 	return defaultDrive, nil
 }
 
+// CheckQuota checks whether a drive has enough remaining space for the given number of bytes before
+// uploading them. If it does not, an *InsufficientQuotaError will be returned.
+//
+// Drives which do not report their quota, e.g. some of the SharePoint document libraries, always pass the check.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/quota?view=odsp-graph-online
+func (s *DrivesService) CheckQuota(ctx context.Context, driveId string, size int64) error {
+	drive, err := s.Get(ctx, driveId)
+	if err != nil {
+		return err
+	}
+
+	if drive.Quota == nil || (drive.Quota.Total == 0 && drive.Quota.State == "") {
+		return nil
+	}
+
+	if !drive.Quota.HasSpaceFor(size) {
+		if driveId == "" {
+			driveId = drive.Id
+		}
+
+		return &InsufficientQuotaError{
+			DriveId:      driveId,
+			RequiredSize: size,
+			Remaining:    drive.Quota.Remaining,
+			State:        drive.Quota.State,
+		}
+	}
+
+	return nil
+}
+
+// GetUserDrive gets the OneDrive of a user by the user ID or the user principal name.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online#get-a-users-onedrive
+func (s *DrivesService) GetUserDrive(ctx context.Context, userIdOrUPN string) (*Drive, error) {
+	if userIdOrUPN == "" {
+		return nil, errors.New("Please provide the ID or the user principal name of the user.")
+	}
+
+	req, err := s.client.NewRequest("GET", "users/"+url.PathEscape(userIdOrUPN)+"/drive", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var drive *Drive
+	err = s.client.Do(ctx, req, false, &drive)
+	if err != nil {
+		return nil, err
+	}
+
+	return drive, nil
+}
+
+// ListUserDrives lists all the drives of a user by the user ID or the user principal name.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_list?view=odsp-graph-online#list-a-users-drives
+func (s *DrivesService) ListUserDrives(ctx context.Context, userIdOrUPN string) (*OneDriveDrivesResponse, error) {
+	if userIdOrUPN == "" {
+		return nil, errors.New("Please provide the ID or the user principal name of the user.")
+	}
+
+	req, err := s.client.NewRequest("GET", "users/"+url.PathEscape(userIdOrUPN)+"/drives", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDrivesResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
 // List all the drives of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_list?view=odsp-graph-online
 func (s *DrivesService) List(ctx context.Context) (*OneDriveDrivesResponse, error) {
-	req, err := s.client.NewRequest("GET", "me/drives", nil)
+	req, err := s.client.NewRequest("GET", s.client.userURL+"/drives", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -80,3 +220,94 @@ func (s *DrivesService) List(ctx context.Context) (*OneDriveDrivesResponse, erro
 
 	return oneDriveResponse, nil
 }
+
+// SharedWithMe lists the items shared with the authenticated user.
+//
+// The items shared with the user are stored in the drives of other users. Hence, the
+// returned items are resolved from their remoteItem references, so that their IDs and
+// the drive IDs in their parentReference point to the actual items in those drives.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_sharedwithme?view=odsp-graph-online
+func (s *DrivesService) SharedWithMe(ctx context.Context) (*OneDriveDriveItemsResponse, error) {
+	return s.listRemoteItems(ctx, s.client.driveURL("")+"/sharedWithMe")
+}
+
+// Recent lists the items recently used by the authenticated user.
+//
+// Some of the recent items may be stored in the drives of other users. Hence, the
+// returned items are resolved from their remoteItem references, so that their IDs and
+// the drive IDs in their parentReference point to the actual items in those drives.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_recent?view=odsp-graph-online
+func (s *DrivesService) Recent(ctx context.Context) (*OneDriveDriveItemsResponse, error) {
+	return s.listRemoteItems(ctx, s.client.driveURL("")+"/recent")
+}
+
+func (s *DrivesService) listRemoteItems(ctx context.Context, apiURL string) (*OneDriveDriveItemsResponse, error) {
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, driveItem := range oneDriveResponse.DriveItems {
+		oneDriveResponse.DriveItems[i] = ResolveRemoteItem(driveItem)
+	}
+
+	return oneDriveResponse, nil
+}
+
+// ResolveRemoteItem returns the drive item referenced by the remoteItem of the given drive item.
+// The returned drive item has the ID of the actual item and a parentReference pointing to the
+// drive storing the actual item, so that it can be used with the other drive item methods.
+//
+// Only the properties present in the remoteItem replace those of the given drive item. The download URL
+// of the given drive item, which is not the one of the actual item, and the remoteItem are cleared.
+//
+// If the given drive item does not reference a remote item, it will be returned as it is.
+func ResolveRemoteItem(driveItem *DriveItem) *DriveItem {
+	if driveItem == nil || driveItem.RemoteItem == nil {
+		return driveItem
+	}
+
+	remoteItem := driveItem.RemoteItem
+
+	resolvedItem := *driveItem
+	resolvedItem.DownloadURL = ""
+	resolvedItem.RemoteItem = nil
+
+	if remoteItem.Id != "" {
+		resolvedItem.Id = remoteItem.Id
+	}
+
+	if remoteItem.ParentReference != nil {
+		resolvedItem.ParentReference = remoteItem.ParentReference
+	}
+
+	if remoteItem.Name != "" {
+		resolvedItem.Name = remoteItem.Name
+	}
+
+	if remoteItem.Size != 0 {
+		resolvedItem.Size = remoteItem.Size
+	}
+
+	if remoteItem.WebURL != "" {
+		resolvedItem.WebURL = remoteItem.WebURL
+	}
+
+	if remoteItem.File != nil {
+		resolvedItem.File = remoteItem.File
+	}
+
+	if remoteItem.Folder != nil {
+		resolvedItem.Folder = remoteItem.Folder
+	}
+
+	return &resolvedItem
+}
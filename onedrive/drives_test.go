@@ -13,6 +13,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestDrivesService_Default_authenticatedUser(t *testing.T) {
@@ -98,3 +99,262 @@ func TestDrivesService_List_authenticatedUser(t *testing.T) {
 	}
 
 }
+
+func TestDrivesService_SharedWithMe_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/sharedWithMe", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_sharedWithMe.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Drives.SharedWithMe(ctx)
+	if err != nil {
+		t.Fatalf("Drives.SharedWithMe returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.DriveItems) != 2 {
+		t.Fatalf("Drives.SharedWithMe returned %v items, want 2", len(gotOneDriveResponse.DriveItems))
+	}
+
+	wantIds := []string{"1991210caf!192", "1bd94ad!99"}
+	wantDriveIds := []string{"1991210caf", "1bd94ad"}
+	for i, driveItem := range gotOneDriveResponse.DriveItems {
+		if driveItem.Id != wantIds[i] {
+			t.Errorf("Drives.SharedWithMe returned item ID %q, want %q", driveItem.Id, wantIds[i])
+		}
+
+		if driveItem.ParentReference == nil || driveItem.ParentReference.DriveId != wantDriveIds[i] {
+			t.Errorf("Drives.SharedWithMe returned parent reference %+v, want drive ID %q", driveItem.ParentReference, wantDriveIds[i])
+		}
+	}
+
+	if gotOneDriveResponse.DriveItems[0].File == nil || gotOneDriveResponse.DriveItems[0].Size != 19121 {
+		t.Errorf("Drives.SharedWithMe returned %+v, want the file details of the remote item", gotOneDriveResponse.DriveItems[0])
+	}
+
+	if gotOneDriveResponse.DriveItems[1].Folder == nil || gotOneDriveResponse.DriveItems[1].Folder.ChildCount != 21 {
+		t.Errorf("Drives.SharedWithMe returned %+v, want the folder details of the remote item", gotOneDriveResponse.DriveItems[1])
+	}
+}
+
+func TestDrivesService_Recent_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/recent", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_sharedWithMe.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	mux.HandleFunc("/drives/1991210caf/items/1991210caf!192", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{"id": "1991210caf!192", "@microsoft.graph.downloadUrl": "`+client.BaseURL.String()+`download/1"}`)
+	})
+
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, "March Proposal")
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Drives.Recent(ctx)
+	if err != nil {
+		t.Fatalf("Drives.Recent returned error: %v", err)
+	}
+
+	// The resolved remote item can be downloaded from the drive storing it.
+	gotContent, err := client.DriveItems.DownloadItem(ctx, gotOneDriveResponse.DriveItems[0])
+	if err != nil {
+		t.Errorf("DriveItems.DownloadItem returned error: %v", err)
+	}
+
+	if string(gotContent) != "March Proposal" {
+		t.Errorf("DriveItems.DownloadItem returned %q, want %q", gotContent, "March Proposal")
+	}
+}
+
+func TestResolveRemoteItem_withoutRemoteItem(t *testing.T) {
+	driveItem := &DriveItem{Id: "1", Name: "Local.txt"}
+
+	if gotDriveItem := ResolveRemoteItem(driveItem); gotDriveItem != driveItem {
+		t.Errorf("ResolveRemoteItem returned %+v, want %+v", gotDriveItem, driveItem)
+	}
+}
+
+func TestResolveRemoteItem_withoutParentReference(t *testing.T) {
+	driveItem := &DriveItem{
+		Id:              "shortcut1",
+		Name:            "Shortcut to Proposal",
+		DownloadURL:     "https://contoso.example/download/shortcut1",
+		ParentReference: &ParentReference{DriveId: "drive1", Id: "folder1"},
+		RemoteItem:      &RemoteItem{Id: "1991210caf!192", Name: "Proposal.docx", File: &DriveItemFile{}},
+	}
+
+	gotDriveItem := ResolveRemoteItem(driveItem)
+
+	if gotDriveItem.Id != "1991210caf!192" || gotDriveItem.Name != "Proposal.docx" || gotDriveItem.File == nil {
+		t.Errorf("ResolveRemoteItem returned %+v, want the properties of the remote item", gotDriveItem)
+	}
+
+	if gotDriveItem.ParentReference == nil || gotDriveItem.ParentReference.DriveId != "drive1" {
+		t.Errorf("ResolveRemoteItem returned parentReference %+v, want the one of the given drive item", gotDriveItem.ParentReference)
+	}
+
+	if gotDriveItem.DownloadURL != "" || gotDriveItem.RemoteItem != nil {
+		t.Errorf("ResolveRemoteItem returned %+v, want the download URL and the remoteItem cleared", gotDriveItem)
+	}
+
+	if driveItem.Id != "shortcut1" || driveItem.RemoteItem == nil {
+		t.Errorf("ResolveRemoteItem modified the given drive item %+v", driveItem)
+	}
+}
+
+func TestDrivesService_GetUserDrive(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_defaultDrive.json")
+	mux.HandleFunc("/users/adele@contoso.com/drive", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotDrive, err := client.Drives.GetUserDrive(ctx, "adele@contoso.com")
+	if err != nil {
+		t.Fatalf("Drives.GetUserDrive returned error: %v", err)
+	}
+
+	var wantDrive *Drive
+	json.Unmarshal(jsonData, &wantDrive)
+
+	if !reflect.DeepEqual(gotDrive, wantDrive) {
+		t.Errorf("Drives.GetUserDrive returned %+v, want %+v", gotDrive, wantDrive)
+	}
+
+	if _, err := client.Drives.GetUserDrive(ctx, ""); err == nil {
+		t.Errorf("Drives.GetUserDrive returned no error for an empty user ID")
+	}
+}
+
+func TestDrivesService_ListUserDrives(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_drives.json")
+	mux.HandleFunc("/users/user1/drives", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Drives.ListUserDrives(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Drives.ListUserDrives returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveDrivesResponse
+	json.Unmarshal(jsonData, &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Drives.ListUserDrives returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestDrivesService_Get_fullDriveModel(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/drive1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, `{
+			"id": "drive1",
+			"name": "Documents",
+			"driveType": "documentLibrary",
+			"createdDateTime": "2020-06-01T08:00:00Z",
+			"webUrl": "https://contoso.sharepoint.com/sites/marketing/Shared%20Documents",
+			"sharePointIds": {"siteId": "site1", "siteUrl": "https://contoso.sharepoint.com/sites/marketing", "listId": "list1"},
+			"system": {},
+			"quota": {"used": 5368709120, "remaining": 27380416512000, "total": 27487790694400, "deleted": 0, "state": "normal"}
+		}`)
+	})
+
+	ctx := context.Background()
+	gotDrive, err := client.Drives.Get(ctx, "drive1")
+	if err != nil {
+		t.Fatalf("Drives.Get returned error: %v", err)
+	}
+
+	wantDrive := &Drive{
+		Id:              "drive1",
+		Name:            "Documents",
+		DriveType:       "documentLibrary",
+		CreatedDateTime: time.Date(2020, 6, 1, 8, 0, 0, 0, time.UTC),
+		WebURL:          "https://contoso.sharepoint.com/sites/marketing/Shared%20Documents",
+		SharePointIds:   &SharePointIds{SiteId: "site1", SiteURL: "https://contoso.sharepoint.com/sites/marketing", ListId: "list1"},
+		System:          &SystemFacet{},
+		Quota:           &DriveQuota{Used: 5368709120, Remaining: 27380416512000, Total: 27487790694400, State: QuotaStateNormal},
+	}
+
+	if !reflect.DeepEqual(gotDrive, wantDrive) {
+		t.Errorf("Drives.Get returned %+v, want %+v", gotDrive, wantDrive)
+	}
+}
+
+func TestDrivesService_CheckQuota(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/full", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "full", "quota": {"used": 5368709120, "remaining": 0, "total": 5368709120, "state": "exceeded"}}`)
+	})
+
+	mux.HandleFunc("/drives/nearlyFull", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "nearlyFull", "quota": {"used": 5368708096, "remaining": 1024, "total": 5368709120, "state": "critical"}}`)
+	})
+
+	mux.HandleFunc("/drives/withoutQuota", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "withoutQuota"}`)
+	})
+
+	ctx := context.Background()
+
+	if err := client.Drives.CheckQuota(ctx, "nearlyFull", 1024); err != nil {
+		t.Errorf("Drives.CheckQuota returned error for an upload fitting the remaining space: %v", err)
+	}
+
+	if err := client.Drives.CheckQuota(ctx, "withoutQuota", 1<<40); err != nil {
+		t.Errorf("Drives.CheckQuota returned error for a drive without quota: %v", err)
+	}
+
+	err := client.Drives.CheckQuota(ctx, "nearlyFull", 1025)
+	wantErr := &InsufficientQuotaError{DriveId: "nearlyFull", RequiredSize: 1025, Remaining: 1024, State: QuotaStateCritical}
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Drives.CheckQuota returned %#v, want %#v", err, wantErr)
+	}
+
+	err = client.Drives.CheckQuota(ctx, "full", 0)
+	if _, ok := err.(*InsufficientQuotaError); !ok {
+		t.Errorf("Drives.CheckQuota returned %v for an exceeded drive, want an *InsufficientQuotaError", err)
+	}
+}
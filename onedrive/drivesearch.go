@@ -7,7 +7,11 @@ package onedrive
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // DriveSearchService handles communication with the drive items searching related methods of the OneDrive API.
@@ -18,13 +22,86 @@ type DriveSearchService service
 // OneDriveDriveSearchResponse represents the JSON object returned by the OneDrive API.
 type OneDriveDriveSearchResponse struct {
 	ODataContext string       `json:"@odata.context"`
+	NextLink     string       `json:"@odata.nextLink"`
 	DriveItems   []*DriveItem `json:"value"`
 }
 
+// SearchOptions represents the optional settings of searching drive items.
+//
+// Select, Top and OrderBy are sent to the OneDrive API while the remaining settings filter the
+// search results on the client side. When Select is used together with the filters, make sure the
+// properties needed by the filters, e.g. name, file or lastModifiedDateTime, are selected.
+type SearchOptions struct {
+	Select   []string // The properties of the drive items to be returned.
+	Top      int      // The number of drive items to be returned in each page of the search results.
+	OrderBy  string   // The order of the search results, e.g. "lastModifiedDateTime desc".
+	AllPages bool     // If true, all the pages of the search results are retrieved; otherwise only the first page is.
+
+	Extensions    []string    // Only the files with one of the extensions, e.g. "mp3" or ".flac", are returned.
+	Facets        []ItemFacet // Only the drive items with one of the facets are returned.
+	ModifiedSince time.Time   // Only the drive items modified at or after this time are returned.
+}
+
 // Search the items in the default drive of the authenticated user.
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online#request
 func (s *DriveSearchService) Search(ctx context.Context, query string) (*OneDriveDriveSearchResponse, error) {
+	return s.SearchInFolder(ctx, "", "", query, nil)
+}
+
+// SearchInDrive searches the items in a drive which the authenticated user can access.
+//
+// Only the first page of the search results is retrieved unless AllPages of the options is true.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online#request
+func (s *DriveSearchService) SearchInDrive(ctx context.Context, driveId string, query string, options *SearchOptions) (*OneDriveDriveSearchResponse, error) {
+	return s.SearchInFolder(ctx, driveId, "", query, options)
+}
+
+// SearchInFolder searches the items in a folder, including its subfolders, of a drive which the authenticated user can access.
+//
+// Only the first page of the search results is retrieved unless AllPages of the options is true.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// If folderId is empty, it means the whole drive will be searched.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online#request
+func (s *DriveSearchService) SearchInFolder(ctx context.Context, driveId string, folderId string, query string, options *SearchOptions) (*OneDriveDriveSearchResponse, error) {
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(folderId)
+	if folderId == "" {
+		apiURL = s.client.itemPathURL(driveId, "", "")
+	}
+
+	return s.search(ctx, apiURL+"/"+searchFunction(query), options, false)
+}
+
+// Search the items in the default drive of the authenticated user as well as items shared with the user.
+//
+// The items shared with the user are resolved from their remoteItem references, so that their IDs and
+// the drive IDs in their parentReference point to the actual items in the drives storing them.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online#searching-for-items-a-user-can-access
+func (s *DriveSearchService) SearchAll(ctx context.Context, query string) (*OneDriveDriveSearchResponse, error) {
+	return s.SearchAllWithOptions(ctx, query, nil)
+}
+
+// SearchAllWithOptions searches the items in the default drive of the authenticated user as well as
+// items shared with the user, just like SearchAll, with additional settings.
+//
+// Only the first page of the search results is retrieved unless AllPages of the options is true.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online#searching-for-items-a-user-can-access
+func (s *DriveSearchService) SearchAllWithOptions(ctx context.Context, query string, options *SearchOptions) (*OneDriveDriveSearchResponse, error) {
+	return s.search(ctx, s.client.driveURL("")+"/"+searchFunction(query), options, true)
+}
+
+// searchFunction returns the search function of the OneDrive API for the given query.
+func searchFunction(query string) string {
 	// For requests that use single quotes, if there are parameter values
 	// also containing single quotes, those must be double escaped; otherwise,
 	// the request will fail due to invalid syntax.
@@ -32,40 +109,132 @@ func (s *DriveSearchService) Search(ctx context.Context, query string) (*OneDriv
 	// Reference: https://docs.microsoft.com/en-us/graph/query-parameters
 	query = strings.Replace(query, "'", "''", -1)
 
-	apiURL := fmt.Sprintf("me/drive/root/search(q='%v')", query)
+	// The query is a part of the URL path, hence characters such as '?', '#' and '%' must be escaped.
+	return fmt.Sprintf("search(q='%v')", url.PathEscape(query))
+}
+
+// search retrieves the first page, or all the pages if AllPages of the options is true, of the search
+// results from the given API URL and then filters them. If resolveRemoteItems is true, the search results referencing remote items will be resolved before filtering.
+func (s *DriveSearchService) search(ctx context.Context, apiURL string, options *SearchOptions, resolveRemoteItems bool) (*OneDriveDriveSearchResponse, error) {
+	if options == nil {
+		options = &SearchOptions{}
+	}
+
+	queryParameters := url.Values{}
+	if len(options.Select) > 0 {
+		queryParameters.Set("$select", strings.Join(options.Select, ","))
+	}
+
+	if options.Top > 0 {
+		queryParameters.Set("$top", strconv.Itoa(options.Top))
+	}
+
+	if options.OrderBy != "" {
+		queryParameters.Set("$orderby", options.OrderBy)
+	}
+
+	if len(queryParameters) > 0 {
+		apiURL += "?" + queryParameters.Encode()
+	}
+
+	var searchResults *OneDriveDriveSearchResponse
+	for apiURL != "" {
+		req, err := s.client.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var oneDriveResponse *OneDriveDriveSearchResponse
+		err = s.client.Do(ctx, req, false, &oneDriveResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		if searchResults == nil {
+			searchResults = oneDriveResponse
+		} else {
+			searchResults.DriveItems = append(searchResults.DriveItems, oneDriveResponse.DriveItems...)
+		}
+
+		if !options.AllPages {
+			break
+		}
+
+		apiURL = oneDriveResponse.NextLink
+	}
+
+	if options.AllPages {
+		searchResults.NextLink = ""
+	}
 
-	req, err := s.client.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, err
+	if resolveRemoteItems {
+		for i, driveItem := range searchResults.DriveItems {
+			searchResults.DriveItems[i] = ResolveRemoteItem(driveItem)
+		}
 	}
 
-	var oneDriveResponse *OneDriveDriveSearchResponse
-	err = s.client.Do(ctx, req, false, &oneDriveResponse)
-	if err != nil {
-		return nil, err
+	if len(options.Extensions) > 0 || len(options.Facets) > 0 || !options.ModifiedSince.IsZero() {
+		var filteredDriveItems []*DriveItem
+		for _, driveItem := range searchResults.DriveItems {
+			if options.isMatched(driveItem) {
+				filteredDriveItems = append(filteredDriveItems, driveItem)
+			}
+		}
+
+		searchResults.DriveItems = filteredDriveItems
 	}
 
-	return oneDriveResponse, nil
+	return searchResults, nil
 }
 
-// Search the items in the default drive of the authenticated user as well as items shared with the user.
-//
-// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online#searching-for-items-a-user-can-access
-func (s *DriveSearchService) SearchAll(ctx context.Context, query string) (*OneDriveDriveSearchResponse, error) {
-	query = strings.Replace(query, "'", "''", -1)
+// isMatched reports whether the given drive item passes all the client side filters.
+func (options *SearchOptions) isMatched(driveItem *DriveItem) bool {
+	if driveItem == nil {
+		return false
+	}
+
+	if len(options.Extensions) > 0 {
+		if driveItem.File == nil {
+			return false
+		}
+
+		itemExtension := strings.ToLower(path.Ext(driveItem.Name))
+
+		isExtensionMatched := false
+		for _, extension := range options.Extensions {
+			extension = strings.ToLower(extension)
+			if !strings.HasPrefix(extension, ".") {
+				extension = "." + extension
+			}
+
+			if itemExtension == extension {
+				isExtensionMatched = true
+				break
+			}
+		}
+
+		if !isExtensionMatched {
+			return false
+		}
+	}
 
-	apiURL := fmt.Sprintf("me/drive/search(q='%v')", query)
+	if len(options.Facets) > 0 {
+		isFacetMatched := false
+		for _, facet := range options.Facets {
+			if facet.hasFacet(driveItem) {
+				isFacetMatched = true
+				break
+			}
+		}
 
-	req, err := s.client.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, err
+		if !isFacetMatched {
+			return false
+		}
 	}
 
-	var oneDriveResponse *OneDriveDriveSearchResponse
-	err = s.client.Do(ctx, req, false, &oneDriveResponse)
-	if err != nil {
-		return nil, err
+	if !options.ModifiedSince.IsZero() && driveItem.LastModifiedDateTime.Before(options.ModifiedSince) {
+		return false
 	}
 
-	return oneDriveResponse, nil
+	return true
 }
@@ -13,6 +13,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestDriveSearchService_SearchWithEmptyQuery_authenticatedUser(t *testing.T) {
@@ -116,3 +117,137 @@ func TestDriveSearchService_SearchAllDriveItems_authenticatedUser(t *testing.T)
 	}
 
 }
+
+func TestDriveSearchService_SearchInFolder_paging(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+	mux.HandleFunc("/drives/drive1/items/folder1/search(q='song')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if r.URL.Query().Get("$skiptoken") == "" {
+			testFormValues(t, r, map[string]string{"$select": "id,name,file,audio,lastModifiedDateTime", "$top": "2", "$orderby": "name"})
+
+			fmt.Fprint(w, `{
+				"@odata.nextLink": "`+serverURL+baseURLPath+`/drives/drive1/items/folder1/search(q='song')?$skiptoken=2",
+				"value": [
+					{"id": "1", "name": "Song 1.MP3", "file": {}, "audio": {}, "lastModifiedDateTime": "2021-03-01T00:00:00Z"},
+					{"id": "2", "name": "Song 2.flac", "file": {}, "audio": {}, "lastModifiedDateTime": "2020-03-01T00:00:00Z"}
+				]
+			}`)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"value": [
+				{"id": "3", "name": "Songs", "folder": {}, "lastModifiedDateTime": "2021-03-01T00:00:00Z"},
+				{"id": "4", "name": "Song 4.flac", "file": {}, "audio": {}, "lastModifiedDateTime": "2021-05-01T00:00:00Z"},
+				{"id": "5", "name": "Song lyrics.txt", "file": {}, "lastModifiedDateTime": "2021-05-01T00:00:00Z"}
+			]
+		}`)
+	})
+
+	options := &SearchOptions{
+		Select:        []string{"id", "name", "file", "audio", "lastModifiedDateTime"},
+		Top:           2,
+		OrderBy:       "name",
+		Extensions:    []string{"mp3", ".FLAC"},
+		Facets:        []ItemFacet{AudioFacet},
+		ModifiedSince: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		AllPages:      true,
+	}
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DriveSearch.SearchInFolder(ctx, "drive1", "folder1", "song", options)
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchInFolder returned error: %v", err)
+	}
+
+	var gotIds []string
+	for _, driveItem := range gotOneDriveResponse.DriveItems {
+		gotIds = append(gotIds, driveItem.Id)
+	}
+
+	if wantIds := []string{"1", "4"}; !reflect.DeepEqual(gotIds, wantIds) {
+		t.Errorf("DriveSearch.SearchInFolder returned items %v, want %v", gotIds, wantIds)
+	}
+
+	if gotOneDriveResponse.NextLink != "" {
+		t.Errorf("DriveSearch.SearchInFolder returned next link %q, want all the pages retrieved", gotOneDriveResponse.NextLink)
+	}
+}
+
+func TestDriveSearchService_Search_firstPageOnly(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	nextLink := serverURL + baseURLPath + "/me/drive/root/search(q='song')?$skiptoken=1"
+	mux.HandleFunc("/me/drive/root/search(q='song')", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$skiptoken") != "" {
+			t.Errorf("Unexpected request to the next page %v", r.URL)
+		}
+
+		fmt.Fprint(w, `{"@odata.nextLink": "`+nextLink+`", "value": [{"id": "1", "name": "Song 1.mp3"}]}`)
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DriveSearch.Search(ctx, "song")
+	if err != nil {
+		t.Fatalf("DriveSearch.Search returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.DriveItems) != 1 || gotOneDriveResponse.NextLink != nextLink {
+		t.Errorf("DriveSearch.Search returned %+v, want the first page with the next link", gotOneDriveResponse)
+	}
+}
+
+func TestDriveSearchService_Search_escapedQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/root/", func(w http.ResponseWriter, r *http.Request) {
+		if want := "/me/drive/root/search(q='it''s 100%? #1')"; r.URL.Path != want {
+			t.Errorf("Request path = %q, want %q", r.URL.Path, want)
+		}
+
+		testFormValues(t, r, map[string]string{"$top": "5"})
+
+		fmt.Fprint(w, `{"value": []}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.DriveSearch.SearchInDrive(ctx, "", "it's 100%? #1", &SearchOptions{Top: 5})
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchInDrive returned error: %v", err)
+	}
+}
+
+func TestDriveSearchService_SearchAll_remoteItems(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+	mux.HandleFunc("/me/drive/search(q='Proposal')", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		jsonData := getTestDataFromFile(t, "fake_sharedWithMe.json")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DriveSearch.SearchAllWithOptions(ctx, "Proposal", &SearchOptions{Facets: []ItemFacet{FileFacet}})
+	if err != nil {
+		t.Fatalf("DriveSearch.SearchAllWithOptions returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.DriveItems) != 1 {
+		t.Fatalf("DriveSearch.SearchAllWithOptions returned %v items, want 1", len(gotOneDriveResponse.DriveItems))
+	}
+
+	gotDriveItem := gotOneDriveResponse.DriveItems[0]
+	if gotDriveItem.Id != "1991210caf!192" || gotDriveItem.ParentReference.DriveId != "1991210caf" {
+		t.Errorf("DriveSearch.SearchAllWithOptions returned %+v, want the resolved remote item", gotDriveItem)
+	}
+}
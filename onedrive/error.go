@@ -9,7 +9,8 @@ type ErrorResponse struct {
 	Error *Error `json:"error"`
 }
 
-// Error represents the error in the response returned by OneDrive drive API.
+// Error represents the error in the response returned by OneDrive drive API. It is returned by the API
+// calls when OneDrive responds with an error, so that the error code can be checked with errors.As.
 type Error struct {
 	Code             string      `json:"code"`
 	Message          string      `json:"message"`
@@ -23,3 +24,11 @@ type InnerError struct {
 	RequestId       string `json:"request-id"`
 	ClientRequestId string `json:"client-request-id"`
 }
+
+func (e *Error) Error() string {
+	if e.InnerError != nil {
+		return e.Code + " - " + e.Message + " (" + e.InnerError.Date + ")"
+	}
+
+	return e.Code + " - " + e.Message
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// GroupsService handles communication with the Microsoft 365 group drives related methods of the OneDrive API.
+//
+// The files of a Microsoft 365 group, including the files shared in the channels of its team in Microsoft Teams,
+// are stored in the drives of the group. Their IDs can be used as the driveId of the drive item methods, so that
+// the items in the group drives can be managed just like the items in the default drive of the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online#get-the-document-library-associated-with-a-group
+type GroupsService service
+
+// GetDefaultDrive gets the default document library of a Microsoft 365 group.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online#get-the-document-library-associated-with-a-group
+func (s *GroupsService) GetDefaultDrive(ctx context.Context, groupId string) (*Drive, error) {
+	if groupId == "" {
+		return nil, errors.New("Please provide the ID of the group.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, "groups/"+url.PathEscape(groupId)+"/drive", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var drive *Drive
+	err = s.client.Do(ctx, req, false, &drive)
+	if err != nil {
+		return nil, err
+	}
+
+	return drive, nil
+}
+
+// ListDrives lists the document libraries of a Microsoft 365 group.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_list?view=odsp-graph-online#list-a-groups-drives
+func (s *GroupsService) ListDrives(ctx context.Context, groupId string) (*OneDriveDrivesResponse, error) {
+	if groupId == "" {
+		return nil, errors.New("Please provide the ID of the group.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, "groups/"+url.PathEscape(groupId)+"/drives", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDrivesResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// GetChannelFilesFolder gets the folder storing the files shared in a channel of a team in Microsoft Teams.
+// The ID of a team is the same as the ID of the Microsoft 365 group behind the team.
+//
+// The drive ID in the parentReference of the returned folder is the ID of the group drive storing the
+// files, which can be used together with the folder ID in the drive item methods.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/channel-get-filesfolder?view=graph-rest-1.0
+func (s *GroupsService) GetChannelFilesFolder(ctx context.Context, teamId string, channelId string) (*DriveItem, error) {
+	if teamId == "" {
+		return nil, errors.New("Please provide the ID of the team.")
+	}
+
+	if channelId == "" {
+		return nil, errors.New("Please provide the ID of the channel.")
+	}
+
+	apiURL := "teams/" + url.PathEscape(teamId) + "/channels/" + url.PathEscape(channelId) + "/filesFolder"
+
+	req, err := s.client.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
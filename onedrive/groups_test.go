@@ -0,0 +1,108 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGroupsService_GetDefaultDrive(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/groups/group1/drive", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"id": "b!wDDMWDz-akmAjfPH8jV8CKS3JVV3LUlPoD9B5xMTsPF0w-Zbbi5eS5FD8nRNHmLy", "name": "Documents", "driveType": "documentLibrary"}`)
+	})
+
+	ctx := context.Background()
+	gotDrive, err := client.Groups.GetDefaultDrive(ctx, "group1")
+	if err != nil {
+		t.Fatalf("Groups.GetDefaultDrive returned error: %v", err)
+	}
+
+	wantDrive := &Drive{Id: "b!wDDMWDz-akmAjfPH8jV8CKS3JVV3LUlPoD9B5xMTsPF0w-Zbbi5eS5FD8nRNHmLy", Name: "Documents", DriveType: "documentLibrary"}
+	if !reflect.DeepEqual(gotDrive, wantDrive) {
+		t.Errorf("Groups.GetDefaultDrive returned %+v, want %+v", gotDrive, wantDrive)
+	}
+
+	if _, err := client.Groups.GetDefaultDrive(ctx, ""); err == nil {
+		t.Errorf("Groups.GetDefaultDrive returned no error for an empty group ID")
+	}
+}
+
+func TestGroupsService_ListDrives(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_siteDrives.json")
+	mux.HandleFunc("/groups/group1/drives", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Groups.ListDrives(ctx, "group1")
+	if err != nil {
+		t.Fatalf("Groups.ListDrives returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveDrivesResponse
+	json.Unmarshal(jsonData, &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Groups.ListDrives returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestGroupsService_GetChannelFilesFolder(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_channelFilesFolder.json")
+	mux.HandleFunc("/teams/team1/channels/19:09fc54a3141a45d0bc769cf506d2e079@thread.skype/filesFolder", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	driveId := "b!wDDMWDz-akmAjfPH8jV8CKS3JVV3LUlPoD9B5xMTsPF0w-Zbbi5eS5FD8nRNHmLy"
+	mux.HandleFunc("/drives/"+driveId+"/items/01RWFXFJG3UYRHE3LP4JAJRA3QIBYRLBBU/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_driveItems.json")))
+	})
+
+	ctx := context.Background()
+	gotFolder, err := client.Groups.GetChannelFilesFolder(ctx, "team1", "19:09fc54a3141a45d0bc769cf506d2e079@thread.skype")
+	if err != nil {
+		t.Fatalf("Groups.GetChannelFilesFolder returned error: %v", err)
+	}
+
+	var wantFolder *DriveItem
+	json.Unmarshal(jsonData, &wantFolder)
+
+	if !reflect.DeepEqual(gotFolder, wantFolder) {
+		t.Errorf("Groups.GetChannelFilesFolder returned %+v, want %+v", gotFolder, wantFolder)
+	}
+
+	if _, err := client.DriveItems.ListInDrive(ctx, gotFolder.ParentReference.DriveId, gotFolder.Id); err != nil {
+		t.Errorf("DriveItems.ListInDrive returned error for the channel files folder: %v", err)
+	}
+
+	if _, err := client.Groups.GetChannelFilesFolder(ctx, "team1", ""); err == nil {
+		t.Errorf("Groups.GetChannelFilesFolder returned no error for an empty channel ID")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// The interfaces below describe the methods of each service of the Client. Callers may depend on them instead
+// of the concrete services, so that the services can be replaced with fakes in tests (see the onedrivemock
+// package) or wrapped with decorators, e.g. to cache, log or skip the requests. A decorator usually embeds the
+// interface and overrides only the methods it is interested in.
+
+// UserAPI is the set of methods of UserService, which is implemented by Client.User.
+type UserAPI interface {
+	GetCurrentUserDetails(ctx context.Context) (*User, error)
+	Get(ctx context.Context, userIdOrUPN string) (*User, error)
+	List(ctx context.Context, pageSize int) (*OneDriveUsersResponse, error)
+	ListNext(ctx context.Context, nextLink string) (*OneDriveUsersResponse, error)
+}
+
+// DrivesAPI is the set of methods of DrivesService, which is implemented by Client.Drives.
+type DrivesAPI interface {
+	Get(ctx context.Context, driveId string) (*Drive, error)
+	CheckQuota(ctx context.Context, driveId string, size int64) error
+	GetUserDrive(ctx context.Context, userIdOrUPN string) (*Drive, error)
+	ListUserDrives(ctx context.Context, userIdOrUPN string) (*OneDriveDrivesResponse, error)
+	List(ctx context.Context) (*OneDriveDrivesResponse, error)
+	SharedWithMe(ctx context.Context) (*OneDriveDriveItemsResponse, error)
+	Recent(ctx context.Context) (*OneDriveDriveItemsResponse, error)
+}
+
+// DriveItemsAPI is the set of methods of DriveItemsService, which is implemented by Client.DriveItems.
+type DriveItemsAPI interface {
+	List(ctx context.Context, folderId string) (*OneDriveDriveItemsResponse, error)
+	ListInDrive(ctx context.Context, driveId string, folderId string) (*OneDriveDriveItemsResponse, error)
+	ListSpecial(ctx context.Context, folderName DriveSpecialFolder) (*OneDriveDriveItemsResponse, error)
+	Delta(ctx context.Context, driveId string, folderId string, deltaLink string) (*OneDriveDeltaResponse, error)
+	Get(ctx context.Context, itemId string) (*DriveItem, error)
+	GetInDrive(ctx context.Context, driveId string, itemId string) (*DriveItem, error)
+	GetSpecial(ctx context.Context, folderName DriveSpecialFolder) (*DriveItem, error)
+	GetByPath(ctx context.Context, driveId string, itemPath string) (*DriveItem, error)
+	ListByPath(ctx context.Context, driveId string, folderPath string) (*OneDriveDriveItemsResponse, error)
+	CreateNewFolder(ctx context.Context, driveId string, parentFolderName string, folderName string) (*DriveItem, error)
+	CreateFolderByPath(ctx context.Context, driveId string, parentFolderPath string, folderName string) (*DriveItem, error)
+	Delete(ctx context.Context, driveId string, itemId string) error
+	PermanentDelete(ctx context.Context, driveId string, itemId string) error
+	DeleteMultiple(ctx context.Context, driveId string, itemIds []string, permanent bool) ([]*DeleteItemResult, error)
+	Restore(ctx context.Context, driveId string, itemId string, destinationParentFolderId string, newItemName string) (*DriveItem, error)
+	Move(ctx context.Context, driveId string, itemId string, destinationParentFolderId string) (*MoveItemResponse, error)
+	Rename(ctx context.Context, driveId string, itemId string, newItemName string) (*RenameItemResponse, error)
+	Copy(ctx context.Context, sourceDriveId string, itemId string, destinationDriveId string, destinationFolderId string, newItemName string) (*CopyItemResponse, error)
+	CopyAndWait(ctx context.Context, sourceDriveId string, itemId string, destinationDriveId string, destinationFolderId string, newItemName string, options *WaitOptions) (*DriveItem, error)
+	UploadNewFile(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string) (*DriveItem, error)
+	UploadNewFileByPath(ctx context.Context, driveId string, destinationFolderPath string, localFilePath string) (*DriveItem, error)
+	UploadToReplaceFile(ctx context.Context, driveId string, localFilePath string, itemId string) (*DriveItem, error)
+	UploadNewFileLarge(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string, sizePerSplit int64) (*DriveItem, error)
+	DownloadItem(ctx context.Context, item *DriveItem) ([]byte, error)
+	DownloadStream(ctx context.Context, driveId string, itemId string) (io.ReadCloser, error)
+	DownloadItemAs(ctx context.Context, item *DriveItem, format DownloadFormat) (io.ReadCloser, error)
+}
+
+// DriveSearchAPI is the set of methods of DriveSearchService, which is implemented by Client.DriveSearch.
+type DriveSearchAPI interface {
+	Search(ctx context.Context, query string) (*OneDriveDriveSearchResponse, error)
+	SearchInDrive(ctx context.Context, driveId string, query string, options *SearchOptions) (*OneDriveDriveSearchResponse, error)
+	SearchInFolder(ctx context.Context, driveId string, folderId string, query string, options *SearchOptions) (*OneDriveDriveSearchResponse, error)
+	SearchAll(ctx context.Context, query string) (*OneDriveDriveSearchResponse, error)
+	SearchAllWithOptions(ctx context.Context, query string, options *SearchOptions) (*OneDriveDriveSearchResponse, error)
+}
+
+// DriveAsyncJobAPI is the set of methods of DriveAsyncJobService, which is implemented by Client.DriveAsyncJob.
+type DriveAsyncJobAPI interface {
+	Monitor(ctx context.Context, monitorUrl string) (*OneDriveAsyncJobMonitorResponse, error)
+	Wait(ctx context.Context, driveId string, monitorUrl string, options *WaitOptions) (*DriveItem, error)
+}
+
+// PermissionAPI is the set of methods of PermissionService, which is implemented by Client.DrivePermissions.
+type PermissionAPI interface {
+	CreateShareLink(ctx context.Context, itemId string, permissionType ShareLinkType, permissionScope ShareLinkScope) (*Permission, error)
+	CreateShareLinkWithOptions(ctx context.Context, driveId string, itemId string, permissionType ShareLinkType, permissionScope ShareLinkScope, options *ShareLinkOptions) (*Permission, error)
+	List(ctx context.Context, itemId string) ([]Permission, error)
+	ListInDrive(ctx context.Context, driveId string, itemId string) ([]Permission, error)
+	Delete(ctx context.Context, driveId string, itemId string, permissionId string) error
+	Invite(ctx context.Context, driveId string, itemId string, invitation *Invitation) ([]Permission, error)
+	Update(ctx context.Context, driveId string, itemId string, permissionId string, update *PermissionUpdate) (*Permission, error)
+}
+
+// SharesAPI is the set of methods of SharesService, which is implemented by Client.Shares.
+type SharesAPI interface {
+	Get(ctx context.Context, shareIdOrURL string, redeem bool) (*SharedDriveItem, error)
+	GetDriveItem(ctx context.Context, shareIdOrURL string, redeem bool) (*DriveItem, error)
+	GetRoot(ctx context.Context, shareIdOrURL string, redeem bool) (*DriveItem, error)
+	ListChildren(ctx context.Context, shareIdOrURL string) (*OneDriveDriveItemsResponse, error)
+	Download(ctx context.Context, shareIdOrURL string) ([]byte, error)
+}
+
+// SubscriptionsAPI is the set of methods of SubscriptionsService, which is implemented by Client.Subscriptions.
+type SubscriptionsAPI interface {
+	Create(ctx context.Context, driveId string, notificationUrl string, clientState string, expirationDateTime time.Time) (*Subscription, error)
+	Renew(ctx context.Context, subscriptionId string, expirationDateTime time.Time) (*Subscription, error)
+	Get(ctx context.Context, subscriptionId string) (*Subscription, error)
+	List(ctx context.Context) (*OneDriveSubscriptionsResponse, error)
+	Delete(ctx context.Context, subscriptionId string) error
+}
+
+// MicrosoftSearchAPI is the set of methods of MicrosoftSearchService, which is implemented by Client.Search.
+type MicrosoftSearchAPI interface {
+	Query(ctx context.Context, queryString string, options *SearchQueryOptions) (*SearchResponse, error)
+}
+
+// SitesAPI is the set of methods of SitesService, which is implemented by Client.Sites.
+type SitesAPI interface {
+	Get(ctx context.Context, siteId string) (*Site, error)
+	GetRoot(ctx context.Context) (*Site, error)
+	GetByPath(ctx context.Context, hostname string, serverRelativePath string) (*Site, error)
+	Search(ctx context.Context, query string) (*OneDriveSitesResponse, error)
+	ListFollowed(ctx context.Context) (*OneDriveSitesResponse, error)
+	GetDefaultDrive(ctx context.Context, siteId string) (*Drive, error)
+	ListDrives(ctx context.Context, siteId string) (*OneDriveDrivesResponse, error)
+}
+
+// GroupsAPI is the set of methods of GroupsService, which is implemented by Client.Groups.
+type GroupsAPI interface {
+	GetDefaultDrive(ctx context.Context, groupId string) (*Drive, error)
+	ListDrives(ctx context.Context, groupId string) (*OneDriveDrivesResponse, error)
+	GetChannelFilesFolder(ctx context.Context, teamId string, channelId string) (*DriveItem, error)
+}
+
+// BundlesAPI is the set of methods of BundlesService, which is implemented by Client.Bundles.
+type BundlesAPI interface {
+	Create(ctx context.Context, name string, itemIds []string, isAlbum bool) (*DriveItem, error)
+	Get(ctx context.Context, bundleId string) (*DriveItem, error)
+	List(ctx context.Context, albumsOnly bool) (*OneDriveDriveItemsResponse, error)
+	ListChildren(ctx context.Context, bundleId string) (*OneDriveDriveItemsResponse, error)
+	AddItem(ctx context.Context, bundleId string, itemId string) error
+	RemoveItem(ctx context.Context, bundleId string, itemId string) error
+	Rename(ctx context.Context, bundleId string, newName string) (*DriveItem, error)
+	Delete(ctx context.Context, bundleId string) error
+}
+
+// The services of the Client must implement the interfaces.
+var (
+	_ UserAPI            = (*UserService)(nil)
+	_ DrivesAPI          = (*DrivesService)(nil)
+	_ DriveItemsAPI      = (*DriveItemsService)(nil)
+	_ DriveSearchAPI     = (*DriveSearchService)(nil)
+	_ DriveAsyncJobAPI   = (*DriveAsyncJobService)(nil)
+	_ PermissionAPI      = (*PermissionService)(nil)
+	_ SharesAPI          = (*SharesService)(nil)
+	_ SubscriptionsAPI   = (*SubscriptionsService)(nil)
+	_ MicrosoftSearchAPI = (*MicrosoftSearchService)(nil)
+	_ SitesAPI           = (*SitesService)(nil)
+	_ GroupsAPI          = (*GroupsService)(nil)
+	_ BundlesAPI         = (*BundlesService)(nil)
+)
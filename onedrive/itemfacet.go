@@ -0,0 +1,48 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// ItemFacet indicates one of the facets which describe what a drive item is
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/driveitem?view=odsp-graph-online#properties
+type ItemFacet int
+
+const (
+	FileFacet ItemFacet = iota
+	FolderFacet
+	AudioFacet
+	PhotoFacet
+	ImageFacet
+	VideoFacet
+	BundleFacet
+	AlbumFacet
+	LocationFacet
+)
+
+// hasFacet reports whether the given drive item has the facet.
+func (itemFacet ItemFacet) hasFacet(driveItem *DriveItem) bool {
+	switch itemFacet {
+	case FileFacet:
+		return driveItem.File != nil
+	case FolderFacet:
+		return driveItem.Folder != nil
+	case AudioFacet:
+		return driveItem.Audio != nil
+	case PhotoFacet:
+		return driveItem.Photo != nil
+	case ImageFacet:
+		return driveItem.Image != nil
+	case VideoFacet:
+		return driveItem.Video != nil
+	case BundleFacet:
+		return driveItem.Bundle != nil
+	case AlbumFacet:
+		return driveItem.Bundle != nil && driveItem.Bundle.Album != nil
+	case LocationFacet:
+		return driveItem.Location != nil
+	}
+
+	return false
+}
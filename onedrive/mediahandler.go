@@ -0,0 +1,362 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMediaURLLifetime is how long the download URL of an item is reused by MediaHandler.
+// The pre-authenticated download URLs returned by the OneDrive API are only valid for a short period of time.
+const defaultMediaURLLifetime = 30 * time.Minute
+
+// defaultMediaCacheSize is the number of files whose download URLs are cached by MediaHandler.
+const defaultMediaCacheSize = 1000
+
+// MediaHandler is an http.Handler which streams the content of the files in a drive, e.g. to the audio
+// and video players of browsers, with the support of HTTP range requests for seeking.
+//
+// The file is identified by the "id" query parameter of the request, or otherwise by the path of the
+// request, which is relative to the root folder of the client, e.g. "/Music/Queen/Bohemian Rhapsody.mp3".
+// Use http.StripPrefix to serve the files under a path prefix.
+//
+// The content is fetched from the pre-authenticated download URL of the file, which is cached and
+// refreshed transparently when it has expired or the file has changed since it was cached. The Content-Type
+// header is set according to the MIME type of the file, and the conditional requests are answered according
+// to the eTag and the last modified time of the file. HEAD requests are answered from the metadata of the
+// file without downloading its content.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online#partial-range-downloads
+type MediaHandler struct {
+	client  *Client
+	driveId string
+
+	// URLLifetime is how long the download URL of a file is reused before it is refreshed. Defaults to 30 minutes.
+	URLLifetime time.Duration
+
+	// CacheSize is the maximum number of files whose download URLs are cached. When the cache is full, the
+	// file cached for the longest time is evicted. Defaults to 1000.
+	CacheSize int
+
+	// HTTPClient is used to fetch the content from the download URLs. Defaults to a new http.Client. As the
+	// download URLs are pre-authenticated, it should not be the authenticated HTTP client of the OneDrive client.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	items map[string]*mediaItem
+}
+
+// mediaItem is a file cached by MediaHandler along with the time its download URL was retrieved.
+type mediaItem struct {
+	driveItem *DriveItem
+	fetchedAt time.Time
+}
+
+// NewMediaHandler returns a new MediaHandler which streams the files in a drive which the authenticated user can access.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+func NewMediaHandler(client *Client, driveId string) *MediaHandler {
+	return &MediaHandler{
+		client:  client,
+		driveId: driveId,
+		items:   make(map[string]*mediaItem),
+	}
+}
+
+// ServeHTTP streams the content of the requested file.
+func (h *MediaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := "path:" + strings.Trim(r.URL.Path, "/")
+	if itemId := r.URL.Query().Get("id"); itemId != "" {
+		key = "id:" + itemId
+	}
+
+	driveItem, isCached, err := h.item(r.Context(), key, false)
+	if err == nil && isCached && r.Header.Get("If-Range") != "" && !isRangeValid(r, quoteETag(driveItem.ETag), mediaLastModified(driveItem)) {
+		// The client may have got a newer version of the file than the cached one. Revalidate the cached file.
+		driveItem, isCached, err = h.item(r.Context(), key, true)
+	}
+
+	if err != nil {
+		var apiError *Error
+		if errors.As(err, &apiError) && apiError.Code == "itemNotFound" {
+			http.Error(w, "File not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to retrieve the file from OneDrive.", http.StatusBadGateway)
+		}
+		return
+	}
+
+	if driveItem.File == nil || driveItem.DownloadURL == "" {
+		http.Error(w, "File not found.", http.StatusNotFound)
+		return
+	}
+
+	if isNotModified(r, quoteETag(driveItem.ETag), mediaLastModified(driveItem)) {
+		setMediaHeaders(w, driveItem)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// A HEAD request is answered with the metadata of the file, without downloading its content.
+	if r.Method == http.MethodHead {
+		setMediaHeaders(w, driveItem)
+		setContentType(w, driveItem)
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Content-Length", strconv.FormatInt(driveItem.Size, 10))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	resp, err := h.fetch(r, driveItem)
+	if err == nil && (isCached && isExpiredURLResponse(resp) || resp.StatusCode == http.StatusPreconditionFailed) {
+		// The cached download URL has expired before its lifetime, or the file has changed since it was
+		// retrieved. Retry with the file retrieved again.
+		resp.Body.Close()
+
+		driveItem, _, err = h.item(r.Context(), key, true)
+		if err == nil {
+			resp, err = h.fetch(r, driveItem)
+		}
+	}
+
+	if err != nil {
+		http.Error(w, "Failed to download the file from OneDrive.", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent && resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		http.Error(w, "Failed to download the file from OneDrive.", http.StatusBadGateway)
+		return
+	}
+
+	setMediaHeaders(w, driveItem)
+	setContentType(w, driveItem)
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	for _, header := range []string{"Content-Length", "Content-Range"} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// item returns the file identified by the key, from the cache unless its download URL has expired or refresh is true.
+// It also reports whether the file was returned from the cache.
+func (h *MediaHandler) item(ctx context.Context, key string, refresh bool) (*DriveItem, bool, error) {
+	urlLifetime := h.URLLifetime
+	if urlLifetime <= 0 {
+		urlLifetime = defaultMediaURLLifetime
+	}
+
+	h.mu.Lock()
+	cachedItem, ok := h.items[key]
+	h.mu.Unlock()
+
+	if ok && !refresh && time.Since(cachedItem.fetchedAt) < urlLifetime {
+		return cachedItem.driveItem, true, nil
+	}
+
+	var driveItem *DriveItem
+	var err error
+	if strings.HasPrefix(key, "id:") {
+		driveItem, err = h.client.DriveItems.GetInDrive(ctx, h.driveId, strings.TrimPrefix(key, "id:"))
+	} else {
+		driveItem, err = h.client.DriveItems.GetByPath(ctx, h.driveId, strings.TrimPrefix(key, "path:"))
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Only the files which can be streamed are cached, so that the requests for arbitrary paths do not fill the cache.
+	if driveItem.File != nil && driveItem.DownloadURL != "" {
+		h.store(key, driveItem, urlLifetime)
+	}
+
+	return driveItem, false, nil
+}
+
+// store caches the file identified by the key after evicting the files whose download URLs have expired and,
+// if the cache is still full, the file cached for the longest time.
+func (h *MediaHandler) store(key string, driveItem *DriveItem, urlLifetime time.Duration) {
+	cacheSize := h.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = defaultMediaCacheSize
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	delete(h.items, key)
+
+	if len(h.items) >= cacheSize {
+		oldestKey := ""
+		var oldestFetchedAt time.Time
+		for cachedKey, cachedItem := range h.items {
+			if now.Sub(cachedItem.fetchedAt) >= urlLifetime {
+				delete(h.items, cachedKey)
+				continue
+			}
+
+			if oldestKey == "" || cachedItem.fetchedAt.Before(oldestFetchedAt) {
+				oldestKey, oldestFetchedAt = cachedKey, cachedItem.fetchedAt
+			}
+		}
+
+		if len(h.items) >= cacheSize {
+			delete(h.items, oldestKey)
+		}
+	}
+
+	h.items[key] = &mediaItem{driveItem: driveItem, fetchedAt: now}
+}
+
+// fetch sends a request to the download URL of a file with the range of the request, if any. The request
+// only succeeds if the file has not changed since it was retrieved, otherwise 412 Precondition Failed is returned.
+func (h *MediaHandler) fetch(r *http.Request, driveItem *DriveItem) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, driveItem.DownloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if eTag := quoteETag(driveItem.ETag); eTag != "" {
+		req.Header.Set("If-Match", eTag)
+	}
+
+	// The range is only forwarded if the file has not changed since the client got the part it has.
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" && isRangeValid(r, quoteETag(driveItem.ETag), mediaLastModified(driveItem)) {
+		req.Header.Set("Range", rangeHeader)
+	}
+
+	httpClient := h.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return httpClient.Do(req.WithContext(r.Context()))
+}
+
+// isExpiredURLResponse reports whether the response indicates that the download URL is no longer valid.
+func isExpiredURLResponse(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		return true
+	}
+
+	return false
+}
+
+// mediaLastModified returns the last modified time of a file in the precision of the HTTP headers.
+func mediaLastModified(driveItem *DriveItem) time.Time {
+	return driveItem.LastModifiedDateTime.UTC().Truncate(time.Second)
+}
+
+// setMediaHeaders sets the validators of a file in the response headers.
+func setMediaHeaders(w http.ResponseWriter, driveItem *DriveItem) {
+	if eTag := quoteETag(driveItem.ETag); eTag != "" {
+		w.Header().Set("ETag", eTag)
+	}
+
+	if lastModified := mediaLastModified(driveItem); !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+}
+
+// setContentType sets the Content-Type header according to the MIME type of a file.
+func setContentType(w http.ResponseWriter, driveItem *DriveItem) {
+	if driveItem.File.MIMEType != "" {
+		w.Header().Set("Content-Type", driveItem.File.MIMEType)
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+}
+
+// isNotModified reports whether the conditional request can be answered with 304 Not Modified.
+func isNotModified(r *http.Request, eTag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if eTag == "" {
+			return false
+		}
+
+		// The entity tags of OneDrive contain commas, so the list cannot simply be split by commas.
+		for ifNoneMatch = strings.TrimSpace(ifNoneMatch); ifNoneMatch != ""; {
+			if ifNoneMatch[0] == '*' {
+				return true
+			}
+
+			candidate := strings.TrimPrefix(ifNoneMatch, "W/")
+			if !strings.HasPrefix(candidate, `"`) {
+				return false
+			}
+
+			end := strings.Index(candidate[1:], `"`)
+			if end < 0 {
+				return false
+			}
+
+			if candidate[:end+2] == eTag {
+				return true
+			}
+
+			ifNoneMatch = strings.TrimLeft(candidate[end+2:], ", \t")
+		}
+
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		if since, err := http.ParseTime(ifModifiedSince); err == nil {
+			return !lastModified.After(since)
+		}
+	}
+
+	return false
+}
+
+// isRangeValid reports whether the range of the request applies according to its If-Range header, if any.
+func isRangeValid(r *http.Request, eTag string, lastModified time.Time) bool {
+	ifRange := strings.TrimSpace(r.Header.Get("If-Range"))
+	if ifRange == "" {
+		return true
+	}
+
+	if strings.HasPrefix(ifRange, `"`) {
+		return eTag != "" && ifRange == eTag
+	}
+
+	if since, err := http.ParseTime(ifRange); err == nil && !lastModified.IsZero() {
+		return lastModified.Equal(since)
+	}
+
+	return false
+}
+
+// quoteETag returns the given eTag as a quoted entity tag of HTTP.
+func quoteETag(eTag string) string {
+	if eTag == "" || strings.HasPrefix(eTag, `"`) || strings.HasPrefix(eTag, `W/"`) {
+		return eTag
+	}
+
+	return `"` + eTag + `"`
+}
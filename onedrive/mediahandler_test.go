@@ -0,0 +1,365 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const mediaContent = "0123456789"
+
+// setupMediaHandler registers a song and its download URL on mux, and returns the number of item requests and download requests.
+func setupMediaHandler(t *testing.T, mux *http.ServeMux, serverURL string) (itemRequests *int, downloadRequests *int) {
+	itemRequests, downloadRequests = new(int), new(int)
+
+	mux.HandleFunc("/me/drive/root:/Music/song.mp3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		*itemRequests++
+
+		fmt.Fprintf(w, `{
+			"id": "song",
+			"name": "song.mp3",
+			"eTag": "aSONG,%d",
+			"size": 10,
+			"lastModifiedDateTime": "2020-05-01T10:00:00Z",
+			"file": {"mimeType": "audio/mpeg"},
+			"@microsoft.graph.downloadUrl": "%s/download/%d"
+		}`, *itemRequests, serverURL+baseURLPath, *itemRequests)
+	})
+
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		*downloadRequests++
+
+		testHeader(t, r, "Authorization", "")
+
+		if r.URL.Path != fmt.Sprintf("/download/%d", *itemRequests) {
+			http.Error(w, "Expired", http.StatusUnauthorized)
+			return
+		}
+
+		if r.Header.Get("Range") == "bytes=2-5" {
+			w.Header().Set("Content-Range", "bytes 2-5/10")
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, mediaContent[2:6])
+			return
+		}
+
+		fmt.Fprint(w, mediaContent)
+	})
+
+	return itemRequests, downloadRequests
+}
+
+func TestMediaHandler_Range(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	setupMediaHandler(t, mux, serverURL)
+
+	handler := NewMediaHandler(client, "")
+
+	req := httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("MediaHandler returned status %d, want %d", rec.Code, http.StatusPartialContent)
+	}
+
+	wantHeaders := map[string]string{
+		"Content-Type":  "audio/mpeg",
+		"Content-Range": "bytes 2-5/10",
+		"Accept-Ranges": "bytes",
+		"ETag":          `"aSONG,1"`,
+		"Last-Modified": "Fri, 01 May 2020 10:00:00 GMT",
+	}
+	for header, want := range wantHeaders {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("MediaHandler returned header %s = %q, want %q", header, got, want)
+		}
+	}
+
+	if got, want := rec.Body.String(), "2345"; got != want {
+		t.Errorf("MediaHandler returned body %q, want %q", got, want)
+	}
+
+	// The range is ignored when the file has changed since the client got the part it has.
+	req = httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	req.Header.Set("If-Range", `"aSONG,0"`)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != mediaContent {
+		t.Errorf("MediaHandler returned status %d and body %q, want %d and %q", rec.Code, rec.Body.String(), http.StatusOK, mediaContent)
+	}
+}
+
+func TestMediaHandler_NotModified(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	_, downloadRequests := setupMediaHandler(t, mux, serverURL)
+
+	handler := NewMediaHandler(client, "")
+
+	conditions := map[string]string{
+		"If-None-Match":     `"aSONG,1"`,
+		"If-Modified-Since": "Sat, 02 May 2020 10:00:00 GMT",
+	}
+	for header, value := range conditions {
+		req := httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil)
+		req.Header.Set(header, value)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("MediaHandler returned status %d for %s, want %d", rec.Code, header, http.StatusNotModified)
+		}
+	}
+
+	if *downloadRequests != 0 {
+		t.Errorf("MediaHandler downloaded the file %d times, want 0", *downloadRequests)
+	}
+}
+
+func TestMediaHandler_ExpiredURL(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	itemRequests, _ := setupMediaHandler(t, mux, serverURL)
+
+	handler := NewMediaHandler(client, "")
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil))
+
+		if rec.Code != http.StatusOK || rec.Body.String() != mediaContent {
+			t.Fatalf("MediaHandler returned status %d and body %q, want %d and %q", rec.Code, rec.Body.String(), http.StatusOK, mediaContent)
+		}
+
+		// Invalidate the download URL cached by the handler.
+		*itemRequests++
+	}
+
+	// The item is requested once for the first request, and once more to refresh the expired download URL.
+	if got, want := *itemRequests-2, 2; got != want {
+		t.Errorf("MediaHandler requested the item %d times, want %d", got, want)
+	}
+}
+
+func TestMediaHandler_Errors(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	setupMediaHandler(t, mux, serverURL)
+
+	mux.HandleFunc("/me/drive/items/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+	})
+
+	handler := NewMediaHandler(client, "")
+
+	tests := []struct {
+		method string
+		target string
+		want   int
+	}{
+		{http.MethodPost, "/Music/song.mp3", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/?id=missing", http.StatusNotFound},
+		{http.MethodHead, "/Music/song.mp3", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(test.method, test.target, nil))
+
+		if rec.Code != test.want {
+			t.Errorf("MediaHandler returned status %d for %s %s, want %d", rec.Code, test.method, test.target, test.want)
+		}
+
+		if body, _ := ioutil.ReadAll(rec.Body); test.method == http.MethodHead && len(body) != 0 {
+			t.Errorf("MediaHandler returned body %q for HEAD, want none", strings.TrimSpace(string(body)))
+		}
+	}
+}
+
+func TestMediaHandler_Cache(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	setupMediaHandler(t, mux, serverURL)
+
+	mux.HandleFunc("/me/drive/root:/Music", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "music", "name": "Music", "folder": {"childCount": 1}}`)
+	})
+
+	mux.HandleFunc("/me/drive/items/", func(w http.ResponseWriter, r *http.Request) {
+		itemId := strings.TrimPrefix(r.URL.Path, "/me/drive/items/")
+		if itemId == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": "itemNotFound", "message": "The resource could not be found."}}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id": "%s", "name": "%s.mp3", "file": {"mimeType": "audio/mpeg"}, "@microsoft.graph.downloadUrl": "%s/download/1"}`,
+			itemId, itemId, serverURL+baseURLPath)
+	})
+
+	handler := NewMediaHandler(client, "")
+	handler.CacheSize = 2
+
+	for _, target := range []string{"/Music", "/?id=missing", "/Music/song.mp3"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	// Neither the folder nor the missing file is cached.
+	if _, ok := handler.items["path:Music/song.mp3"]; !ok || len(handler.items) != 1 {
+		t.Errorf("MediaHandler cached %d items, want the song only", len(handler.items))
+	}
+
+	for _, itemId := range []string{"a", "b"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?id="+itemId, nil))
+	}
+
+	// The song, which was cached for the longest time, is evicted when the cache is full.
+	if _, ok := handler.items["path:Music/song.mp3"]; ok || len(handler.items) != 2 {
+		t.Errorf("MediaHandler cached %d items, want the last 2 items only", len(handler.items))
+	}
+}
+
+func TestMediaHandler_Head(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	_, downloadRequests := setupMediaHandler(t, mux, serverURL)
+
+	handler := NewMediaHandler(client, "")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/Music/song.mp3", nil))
+
+	wantHeaders := map[string]string{
+		"Content-Type":   "audio/mpeg",
+		"Content-Length": "10",
+		"Accept-Ranges":  "bytes",
+		"ETag":           `"aSONG,1"`,
+	}
+	for header, want := range wantHeaders {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("MediaHandler returned header %s: %q, want %q", header, got, want)
+		}
+	}
+
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("MediaHandler returned status %d and %d bytes for HEAD, want %d and no body", rec.Code, rec.Body.Len(), http.StatusOK)
+	}
+
+	if *downloadRequests != 0 {
+		t.Errorf("MediaHandler downloaded the file %d times for HEAD, want 0", *downloadRequests)
+	}
+}
+
+// setupChangingMediaHandler registers a song whose version can be changed, and whose download URL only serves
+// the content if the If-Match header matches the current version. It returns the version and the number of item requests.
+func setupChangingMediaHandler(t *testing.T, mux *http.ServeMux, serverURL string) (version *int, itemRequests *int) {
+	version, itemRequests = new(int), new(int)
+	*version = 1
+
+	mux.HandleFunc("/me/drive/root:/Music/song.mp3", func(w http.ResponseWriter, r *http.Request) {
+		*itemRequests++
+
+		fmt.Fprintf(w, `{"id": "song", "name": "song.mp3", "eTag": "v%d", "file": {"mimeType": "audio/mpeg"}, "@microsoft.graph.downloadUrl": "%s/download/song"}`,
+			*version, serverURL+baseURLPath)
+	})
+
+	mux.HandleFunc("/download/song", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != fmt.Sprintf(`"v%d"`, *version) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		if r.Header.Get("Range") == "bytes=2-5" {
+			w.Header().Set("Content-Range", "bytes 2-5/10")
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, mediaContent[2:6])
+			return
+		}
+
+		fmt.Fprint(w, mediaContent)
+	})
+
+	return version, itemRequests
+}
+
+func TestMediaHandler_ChangedFile(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	version, itemRequests := setupChangingMediaHandler(t, mux, serverURL)
+
+	handler := NewMediaHandler(client, "")
+
+	for i := 1; i <= 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil))
+
+		if want := fmt.Sprintf(`"v%d"`, i); rec.Code != http.StatusOK || rec.Header().Get("ETag") != want {
+			t.Errorf("MediaHandler returned status %d and ETag %q, want %d and %q", rec.Code, rec.Header().Get("ETag"), http.StatusOK, want)
+		}
+
+		// The file is changed after the cached one has been served.
+		*version++
+	}
+
+	// The item is requested once for the first request, and once more when the download fails with 412.
+	if *itemRequests != 2 {
+		t.Errorf("MediaHandler requested the item %d times, want 2", *itemRequests)
+	}
+}
+
+func TestMediaHandler_StaleIfRange(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	version, itemRequests := setupChangingMediaHandler(t, mux, serverURL)
+
+	handler := NewMediaHandler(client, "")
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil))
+
+	// The client has got a part of a newer version of the file than the cached one.
+	*version++
+
+	req := httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	req.Header.Set("If-Range", `"v2"`)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != mediaContent[2:6] {
+		t.Errorf("MediaHandler returned status %d and body %q, want %d and %q", rec.Code, rec.Body.String(), http.StatusPartialContent, mediaContent[2:6])
+	}
+
+	if *itemRequests != 2 {
+		t.Errorf("MediaHandler requested the item %d times, want 2", *itemRequests)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package music
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes the index of the library, i.e. the result of Artists, to w as JSON.
+func (l *Library) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(l.Artists())
+}
+
+// WriteM3U writes the given tracks to w as an extended M3U playlist, in the given order.
+//
+// The location of each track in the playlist is returned by trackURL, e.g. the URL of an endpoint
+// streaming the track. If trackURL is nil, the web URL of the track in OneDrive will be used.
+func WriteM3U(w io.Writer, tracks []*Track, trackURL func(track *Track) string) error {
+	if trackURL == nil {
+		trackURL = func(track *Track) string {
+			return track.WebURL
+		}
+	}
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintln(writer, "#EXTM3U")
+
+	for _, track := range tracks {
+		location := m3uText(trackURL(track))
+		if location == "" {
+			continue
+		}
+
+		seconds := int(track.Duration.Seconds())
+		if seconds <= 0 {
+			seconds = -1
+		}
+
+		fmt.Fprintf(writer, "#EXTINF:%d,%s - %s\n", seconds, m3uText(track.Artist), m3uText(track.Title))
+		fmt.Fprintln(writer, location)
+	}
+
+	return writer.Flush()
+}
+
+// WriteM3U writes all the tracks of the library to w as an extended M3U playlist, in the order of Tracks.
+// See the package level WriteM3U for trackURL.
+func (l *Library) WriteM3U(w io.Writer, trackURL func(track *Track) string) error {
+	return WriteM3U(w, l.Tracks(), trackURL)
+}
+
+// m3uText returns the given text without line breaks, which would otherwise break the playlist.
+func m3uText(text string) string {
+	return strings.TrimSpace(strings.NewReplacer("\r", " ", "\n", " ").Replace(text))
+}
@@ -0,0 +1,355 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package music builds a music library from the audio files stored in OneDrive.
+//
+// The library indexes the audio files in a folder, the Music special folder by default, into
+// artists, albums and tracks according to their audio metadata, and keeps the index current
+// by tracking the changes of the folder. For example:
+//
+//	library := music.NewLibrary(client, nil)
+//	if err := library.Refresh(ctx); err != nil {
+//		...
+//	}
+//
+//	for _, track := range library.Tracks() {
+//		content, err := library.Open(ctx, track)
+//		...
+//	}
+package music
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+const (
+	unknownArtist = "Unknown Artist"
+	unknownAlbum  = "Unknown Album"
+)
+
+// Track represents an audio file in the library.
+type Track struct {
+	Id          string        `json:"id"`
+	DriveId     string        `json:"driveId,omitempty"`
+	FileName    string        `json:"fileName"`
+	Title       string        `json:"title"`
+	Artist      string        `json:"artist"`
+	AlbumArtist string        `json:"albumArtist"`
+	Album       string        `json:"album"`
+	Genre       string        `json:"genre,omitempty"`
+	Disc        int           `json:"disc,omitempty"`
+	Track       int           `json:"track,omitempty"`
+	Year        int           `json:"year,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	Size        int64         `json:"size"`
+	MIMEType    string        `json:"mimeType,omitempty"`
+	WebURL      string        `json:"webUrl,omitempty"`
+}
+
+// Album represents an album in the library with its tracks in the playing order.
+type Album struct {
+	Title  string   `json:"title"`
+	Artist string   `json:"artist"`
+	Year   int      `json:"year,omitempty"`
+	Tracks []*Track `json:"tracks"`
+}
+
+// Artist represents an album artist in the library with the albums sorted by their year and title.
+type Artist struct {
+	Name   string   `json:"name"`
+	Albums []*Album `json:"albums"`
+}
+
+// Options represents the settings of a library.
+type Options struct {
+	// DriveId is the ID of the drive storing the music. Defaults to the default drive of the user of the client.
+	DriveId string
+
+	// FolderId is the ID of the folder storing the music. Defaults to the Music special folder,
+	// which is only available in the default drive of the user of the client.
+	FolderId string
+}
+
+// Library is an in-memory index of the audio files in a OneDrive folder. It is safe for concurrent use.
+type Library struct {
+	client   *onedrive.Client
+	driveId  string
+	folderId string
+
+	mu        sync.RWMutex
+	deltaLink string
+	tracks    map[string]*Track
+}
+
+// NewLibrary returns a new empty library of the audio files in the folder specified by the options.
+// A nil options means the Music special folder of the user of the client. Call Refresh to build the index.
+func NewLibrary(client *onedrive.Client, options *Options) *Library {
+	if options == nil {
+		options = &Options{}
+	}
+
+	return &Library{
+		client:   client,
+		driveId:  options.DriveId,
+		folderId: options.FolderId,
+		tracks:   make(map[string]*Track),
+	}
+}
+
+// Refresh brings the index up to date with the folder. The first call indexes all the audio files in the
+// folder, including its subfolders, while the subsequent calls only apply the changes made since the last call.
+// If the changes since the last call are no longer available, the folder is indexed from scratch again.
+func (l *Library) Refresh(ctx context.Context) error {
+	l.mu.RLock()
+	deltaLink := l.deltaLink
+	folderId := l.folderId
+	l.mu.RUnlock()
+
+	if deltaLink == "" && folderId == "" {
+		if l.driveId != "" {
+			return errors.New("Please provide the ID of the music folder in the drive.")
+		}
+
+		musicFolder, err := l.client.DriveItems.GetSpecial(ctx, onedrive.Music)
+		if err != nil {
+			return err
+		}
+
+		folderId = musicFolder.Id
+	}
+
+	var resp onedrive.Response
+	changes, err := l.client.DriveItems.Delta(onedrive.WithResponse(ctx, &resp), l.driveId, folderId, deltaLink)
+	if err != nil && deltaLink != "" && isResyncRequired(err, &resp) {
+		// The delta token has expired. The next calls start over even if indexing from scratch fails now.
+		l.mu.Lock()
+		l.deltaLink = ""
+		l.mu.Unlock()
+
+		deltaLink = ""
+		changes, err = l.client.DriveItems.Delta(ctx, l.driveId, folderId, "")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Without a delta link, the changes are the whole content of the folder, which replaces the index.
+	if deltaLink == "" {
+		l.tracks = make(map[string]*Track)
+	}
+
+	l.folderId = folderId
+	for _, driveItem := range changes.DriveItems {
+		if driveItem.Deleted != nil || driveItem.Audio == nil || driveItem.File == nil {
+			delete(l.tracks, driveItem.Id)
+			continue
+		}
+
+		l.tracks[driveItem.Id] = newTrack(driveItem)
+	}
+
+	l.deltaLink = changes.DeltaLink
+
+	return nil
+}
+
+// isResyncRequired reports whether the delta token has expired, so that the changes must be tracked from scratch.
+func isResyncRequired(err error, resp *onedrive.Response) bool {
+	var apiError *onedrive.Error
+	if errors.As(err, &apiError) && strings.HasPrefix(apiError.Code, "resync") {
+		return true
+	}
+
+	return resp.Response != nil && resp.StatusCode == http.StatusGone
+}
+
+// newTrack returns the track of the given audio file. The missing metadata is filled with the best guess.
+func newTrack(driveItem *onedrive.DriveItem) *Track {
+	audio := driveItem.Audio
+
+	track := &Track{
+		Id:          driveItem.Id,
+		FileName:    driveItem.Name,
+		Title:       strings.TrimSpace(audio.Title),
+		Artist:      strings.TrimSpace(audio.Artist),
+		AlbumArtist: strings.TrimSpace(audio.AlbumArtist),
+		Album:       strings.TrimSpace(audio.Album),
+		Genre:       audio.Genre,
+		Disc:        audio.Disc,
+		Track:       audio.Track,
+		Year:        audio.Year,
+		Duration:    time.Duration(audio.Duration) * time.Millisecond,
+		Size:        driveItem.Size,
+		MIMEType:    driveItem.File.MIMEType,
+		WebURL:      driveItem.WebURL,
+	}
+
+	if driveItem.ParentReference != nil {
+		track.DriveId = driveItem.ParentReference.DriveId
+	}
+
+	if track.Title == "" {
+		track.Title = strings.TrimSuffix(driveItem.Name, path.Ext(driveItem.Name))
+	}
+
+	if track.Artist == "" {
+		track.Artist = track.AlbumArtist
+	}
+
+	if track.AlbumArtist == "" {
+		track.AlbumArtist = track.Artist
+	}
+
+	if track.AlbumArtist == "" {
+		track.AlbumArtist = unknownArtist
+		track.Artist = unknownArtist
+	}
+
+	if track.Album == "" {
+		track.Album = unknownAlbum
+	}
+
+	return track
+}
+
+// Len returns the number of tracks in the library.
+func (l *Library) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.tracks)
+}
+
+// Track returns the track of the audio file with the given ID, or nil if it is not in the library.
+func (l *Library) Track(id string) *Track {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.tracks[id]
+}
+
+// Tracks returns all the tracks in a stable playing order, i.e. sorted by album artist, album year,
+// album title, disc number, track number, title and then ID.
+func (l *Library) Tracks() []*Track {
+	var tracks []*Track
+	for _, artist := range l.Artists() {
+		for _, album := range artist.Albums {
+			tracks = append(tracks, album.Tracks...)
+		}
+	}
+
+	return tracks
+}
+
+// Artists returns the index of the library, i.e. the album artists sorted by name, each with
+// its albums sorted by year and title, and each album with its tracks in the playing order.
+func (l *Library) Artists() []*Artist {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	artistsByName := make(map[string]*Artist)
+	albumsByKey := make(map[string]*Album)
+	var artists []*Artist
+
+	for _, track := range l.tracks {
+		artistKey := strings.ToLower(track.AlbumArtist)
+		artist, ok := artistsByName[artistKey]
+		if !ok {
+			artist = &Artist{Name: track.AlbumArtist}
+			artistsByName[artistKey] = artist
+			artists = append(artists, artist)
+		}
+
+		albumKey := artistKey + "\x00" + strings.ToLower(track.Album)
+		album, ok := albumsByKey[albumKey]
+		if !ok {
+			album = &Album{Title: track.Album, Artist: track.AlbumArtist}
+			albumsByKey[albumKey] = album
+			artist.Albums = append(artist.Albums, album)
+		}
+
+		if track.Year > album.Year {
+			album.Year = track.Year
+		}
+
+		trackCopy := *track
+		album.Tracks = append(album.Tracks, &trackCopy)
+	}
+
+	sort.Slice(artists, func(i, j int) bool {
+		return lessFold(artists[i].Name, artists[j].Name)
+	})
+
+	for _, artist := range artists {
+		albums := artist.Albums
+		sort.Slice(albums, func(i, j int) bool {
+			if albums[i].Year != albums[j].Year {
+				return albums[i].Year < albums[j].Year
+			}
+
+			return lessFold(albums[i].Title, albums[j].Title)
+		})
+
+		for _, album := range albums {
+			tracks := album.Tracks
+			sort.Slice(tracks, func(i, j int) bool {
+				if tracks[i].Disc != tracks[j].Disc {
+					return tracks[i].Disc < tracks[j].Disc
+				}
+
+				if tracks[i].Track != tracks[j].Track {
+					return tracks[i].Track < tracks[j].Track
+				}
+
+				if !strings.EqualFold(tracks[i].Title, tracks[j].Title) {
+					return lessFold(tracks[i].Title, tracks[j].Title)
+				}
+
+				return tracks[i].Id < tracks[j].Id
+			})
+		}
+	}
+
+	return artists
+}
+
+// lessFold reports whether a sorts before b, ignoring the case, with the exact strings as the tie-breaker.
+func lessFold(a string, b string) bool {
+	lowerA, lowerB := strings.ToLower(a), strings.ToLower(b)
+	if lowerA != lowerB {
+		return lowerA < lowerB
+	}
+
+	return a < b
+}
+
+// Open opens a download stream of the content of the track, which must be closed by the caller.
+// A fresh download URL is retrieved every time, so that tracks can be opened one after another
+// while they are being played, no matter how long ago the library was refreshed.
+func (l *Library) Open(ctx context.Context, track *Track) (io.ReadCloser, error) {
+	if track == nil {
+		return nil, errors.New("Please provide the track to be opened.")
+	}
+
+	driveId := track.DriveId
+	if driveId == "" {
+		driveId = l.driveId
+	}
+
+	return l.client.DriveItems.DownloadStream(ctx, driveId, track.Id)
+}
@@ -0,0 +1,254 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package music
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// setup sets up a test HTTP server along with a onedrive.Client that is configured to talk to that test server.
+func setup() (client *onedrive.Client, mux *http.ServeMux, serverURL string, teardown func()) {
+	mux = http.NewServeMux()
+
+	server := httptest.NewServer(mux)
+
+	client = onedrive.NewClient(nil)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client.BaseURL = baseURL
+
+	return client, mux, server.URL, server.Close
+}
+
+func audioItem(id string, name string, audio string) string {
+	return fmt.Sprintf(`{"id": %q, "name": %q, "size": 1024, "webUrl": "https://onedrive.live.com/%s", "file": {"mimeType": "audio/mpeg"}, "audio": %s}`, id, name, id, audio)
+}
+
+func TestLibrary_Refresh(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/special/music", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "music", "name": "Music", "folder": {"childCount": 2}}`)
+	})
+
+	deltaRequests := 0
+	mux.HandleFunc("/me/drive/items/music/delta", func(w http.ResponseWriter, r *http.Request) {
+		deltaRequests++
+
+		switch r.FormValue("token") {
+		case "":
+			fmt.Fprintf(w, `{"@odata.nextLink": "%s/me/drive/items/music/delta?token=page2", "value": [
+				{"id": "music", "name": "Music", "folder": {"childCount": 2}},
+				%s,
+				%s
+			]}`, serverURL,
+				audioItem("song2", "02 Track.mp3", `{"title": "You're My Best Friend", "artist": "Queen", "album": "A Night at the Opera", "track": 4, "year": 1975, "duration": 172000}`),
+				audioItem("song1", "01 Track.mp3", `{"title": "Bohemian Rhapsody", "artist": "Queen", "albumArtist": "Queen", "album": "A Night at the Opera", "track": 11, "year": 1975, "duration": 354320}`))
+		case "page2":
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/me/drive/items/music/delta?token=latest", "value": [
+				{"id": "cover", "name": "cover.jpg", "file": {"mimeType": "image/jpeg"}},
+				%s,
+				%s
+			]}`, serverURL,
+				audioItem("song3", "Untitled.mp3", `{}`),
+				audioItem("song4", "Heroes.mp3", `{"title": "Heroes", "albumArtist": "David Bowie", "album": "Heroes", "year": 1977, "disc": 1, "track": 3}`))
+		case "latest":
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/me/drive/items/music/delta?token=latest2", "value": [
+				{"id": "song3", "name": "Untitled.mp3", "deleted": {"state": "deleted"}},
+				%s
+			]}`, serverURL,
+				audioItem("song2", "02 Track.mp3", `{"title": "You're My Best Friend", "artist": "Queen", "album": "A Night at the Opera", "track": 4, "year": 1975, "genre": "Rock"}`))
+		default:
+			t.Errorf("Unexpected delta token %q", r.FormValue("token"))
+		}
+	})
+
+	library := NewLibrary(client, nil)
+
+	ctx := context.Background()
+	if err := library.Refresh(ctx); err != nil {
+		t.Fatalf("Library.Refresh returned error: %v", err)
+	}
+
+	if got := library.Len(); got != 4 {
+		t.Errorf("Library.Len returned %d after the first refresh, want 4", got)
+	}
+
+	var gotTitles []string
+	for _, track := range library.Tracks() {
+		gotTitles = append(gotTitles, track.AlbumArtist+"/"+track.Album+"/"+track.Title)
+	}
+
+	wantTitles := []string{
+		"David Bowie/Heroes/Heroes",
+		"Queen/A Night at the Opera/You're My Best Friend",
+		"Queen/A Night at the Opera/Bohemian Rhapsody",
+		"Unknown Artist/Unknown Album/Untitled",
+	}
+	if !reflect.DeepEqual(gotTitles, wantTitles) {
+		t.Errorf("Library.Tracks returned %q, want %q", gotTitles, wantTitles)
+	}
+
+	if err := library.Refresh(ctx); err != nil {
+		t.Fatalf("Library.Refresh returned error: %v", err)
+	}
+
+	if deltaRequests != 3 {
+		t.Errorf("Library.Refresh sent %d delta requests, want 3", deltaRequests)
+	}
+
+	if library.Track("song3") != nil {
+		t.Errorf("Library.Track returned the deleted track")
+	}
+
+	if got := library.Track("song2").Genre; got != "Rock" {
+		t.Errorf("Library.Track returned genre %q for the updated track, want Rock", got)
+	}
+
+	artists := library.Artists()
+	if len(artists) != 2 || artists[1].Name != "Queen" || artists[1].Albums[0].Year != 1975 || len(artists[1].Albums[0].Tracks) != 2 {
+		t.Errorf("Library.Artists returned an unexpected index: %+v", artists)
+	}
+}
+
+func TestLibrary_Refresh_resyncRequired(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	enumerations := 0
+	mux.HandleFunc("/drives/drive1/items/music/delta", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("token") {
+		case "":
+			enumerations++
+
+			// The first song is removed from the folder while the delta token is expired.
+			songs := audioItem("song1", "Bohemian Rhapsody.mp3", `{"artist": "Queen"}`) + "," + audioItem("song2", "Heroes.mp3", `{"artist": "David Bowie"}`)
+			if enumerations > 1 {
+				songs = audioItem("song2", "Heroes.mp3", `{"artist": "David Bowie"}`)
+			}
+
+			fmt.Fprintf(w, `{"@odata.deltaLink": "%s/drives/drive1/items/music/delta?token=expired", "value": [%s]}`, serverURL, songs)
+		case "expired":
+			w.WriteHeader(http.StatusGone)
+			fmt.Fprint(w, `{"error": {"code": "resyncRequired", "message": "Resync required."}}`)
+		default:
+			t.Errorf("Unexpected delta token %q", r.FormValue("token"))
+		}
+	})
+
+	library := NewLibrary(client, &Options{DriveId: "drive1", FolderId: "music"})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := library.Refresh(ctx); err != nil {
+			t.Fatalf("Library.Refresh returned error: %v", err)
+		}
+	}
+
+	if enumerations != 2 {
+		t.Errorf("Library.Refresh enumerated the folder %d times, want 2", enumerations)
+	}
+
+	if library.Len() != 1 || library.Track("song1") != nil || library.Track("song2") == nil {
+		t.Errorf("Library.Tracks returned %+v after the resync, want the second song only", library.Tracks())
+	}
+}
+
+func TestLibrary_Open(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/drives/drive1/items/song1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "song1", "name": "01 Track.mp3", "@microsoft.graph.downloadUrl": "%s/download/song1"}`, serverURL)
+	})
+
+	mux.HandleFunc("/download/song1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ID3")
+	})
+
+	library := NewLibrary(client, &Options{DriveId: "drive1", FolderId: "music"})
+
+	ctx := context.Background()
+	content, err := library.Open(ctx, &Track{Id: "song1"})
+	if err != nil {
+		t.Fatalf("Library.Open returned error: %v", err)
+	}
+	defer content.Close()
+
+	gotContent, _ := ioutil.ReadAll(content)
+	if string(gotContent) != "ID3" {
+		t.Errorf("Library.Open returned %q, want the content of the track", gotContent)
+	}
+}
+
+func TestWriteM3U(t *testing.T) {
+	tracks := []*Track{
+		{Id: "1", Title: "Bohemian\nRhapsody", Artist: "Queen", Duration: 354320000000, WebURL: "https://onedrive.live.com/1"},
+		{Id: "2", Title: "Heroes", Artist: "David Bowie"},
+	}
+
+	var buffer bytes.Buffer
+	err := WriteM3U(&buffer, tracks, func(track *Track) string {
+		return "http://localhost:8080/tracks/" + track.Id
+	})
+	if err != nil {
+		t.Fatalf("WriteM3U returned error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"#EXTM3U",
+		"#EXTINF:354,Queen - Bohemian Rhapsody",
+		"http://localhost:8080/tracks/1",
+		"#EXTINF:-1,David Bowie - Heroes",
+		"http://localhost:8080/tracks/2",
+		"",
+	}, "\n")
+	if got := buffer.String(); got != want {
+		t.Errorf("WriteM3U wrote %q, want %q", got, want)
+	}
+
+	buffer.Reset()
+	if err := WriteM3U(&buffer, tracks, nil); err != nil {
+		t.Fatalf("WriteM3U returned error: %v", err)
+	}
+
+	if got := buffer.String(); strings.Contains(got, "Heroes") || !strings.Contains(got, "https://onedrive.live.com/1") {
+		t.Errorf("WriteM3U wrote %q, want only the track with a web URL", got)
+	}
+}
+
+func TestLibrary_WriteJSON(t *testing.T) {
+	library := NewLibrary(nil, nil)
+	library.tracks["1"] = &Track{Id: "1", Title: "Heroes", Artist: "David Bowie", AlbumArtist: "David Bowie", Album: "Heroes", Year: 1977}
+
+	var buffer bytes.Buffer
+	if err := library.WriteJSON(&buffer); err != nil {
+		t.Fatalf("Library.WriteJSON returned error: %v", err)
+	}
+
+	var gotArtists []*Artist
+	if err := json.Unmarshal(buffer.Bytes(), &gotArtists); err != nil {
+		t.Fatalf("Library.WriteJSON wrote invalid JSON: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotArtists, library.Artists()) {
+		t.Errorf("Library.WriteJSON wrote %+v, want %+v", gotArtists, library.Artists())
+	}
+}
@@ -18,10 +18,13 @@ import (
 )
 
 const (
-	defaultBaseURL  = "https://graph.microsoft.com/v1.0/"
-	oneDriveBaseUrl = "https://api.onedrive.com/v1.0/"
+	defaultBaseURL = "https://graph.microsoft.com/v1.0/"
 )
 
+// defaultMonitorHosts are the hosts of the monitor URLs of async jobs returned by the OneDrive API,
+// i.e. OneDrive personal and OneDrive for Business / SharePoint Online in the global cloud.
+var defaultMonitorHosts = []string{"api.onedrive.com", "*.sharepoint.com"}
+
 type service struct {
 	client *Client
 }
@@ -34,6 +37,27 @@ type Client struct {
 	// always be specified with a trailing slash.
 	BaseURL *url.URL
 
+	// Hosts which the monitor URLs of async jobs are allowed to point to. A host starting with "*."
+	// matches any subdomain of the rest of the host, e.g. "*.sharepoint.com" matches "contoso.sharepoint.com".
+	// Defaults to the hosts of OneDrive personal and SharePoint Online in the global cloud. Hosts of
+	// the national clouds, such as "*.sharepoint.us" or "*.sharepoint.cn", can be appended when needed.
+	// An origin, e.g. "http://127.0.0.1:8080", allows exactly the scheme, host and port of the origin,
+	// such as those of a test server.
+	MonitorHosts []string
+
+	// Whether the quota of the drive is checked before uploading a file, which costs an extra request for each
+	// upload. If the drive does not have enough space for the file, an *InsufficientQuotaError is returned
+	// without uploading anything.
+	CheckQuotaBeforeUpload bool
+
+	// userURL is the relative URL of the user whose resources are accessed when no drive is specified.
+	// Defaults to "me", i.e. the authenticated user. See ForUser.
+	userURL string
+
+	// rootURL is the relative URL, within a drive, of the folder which the item paths are relative to.
+	// Defaults to "root", i.e. the root folder of the drive. See AppFolder.
+	rootURL string
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the OneDrive API.
@@ -43,6 +67,12 @@ type Client struct {
 	DriveSearch      *DriveSearchService
 	DriveAsyncJob    *DriveAsyncJobService
 	DrivePermissions *PermissionService
+	Shares           *SharesService
+	Subscriptions    *SubscriptionsService
+	Search           *MicrosoftSearchService
+	Sites            *SitesService
+	Groups           *GroupsService
+	Bundles          *BundlesService
 }
 
 // NewClient returns a new OneDrive API client. If a nil httpClient is
@@ -55,8 +85,48 @@ func NewClient(httpClient *http.Client) *Client {
 	}
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	c := &Client{client: httpClient, BaseURL: baseURL}
+	c := &Client{client: httpClient, BaseURL: baseURL, userURL: "me", rootURL: "root"}
+	c.MonitorHosts = append([]string(nil), defaultMonitorHosts...)
+	c.initialize()
+
+	return c
+}
+
+// ForUser returns a copy of the client which accesses the resources of the given user, identified by
+// the user ID or the user principal name, instead of the resources of the authenticated user. The
+// methods of the returned client which default to the drive of the authenticated user, e.g.
+// DriveItems.List, default to the drive of the given user instead.
+//
+// This is required when the client is authenticated with application permissions, e.g. with
+// a token issued by the client credentials flow, because there is no signed-in user behind "me".
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online#get-a-users-onedrive
+func (c *Client) ForUser(userIdOrUPN string) *Client {
+	clone := *c
+	clone.userURL = "users/" + url.PathEscape(userIdOrUPN)
+	clone.initialize()
+
+	return &clone
+}
+
+// AppFolder returns a copy of the client whose item paths, as well as the root folder used when no
+// folder is specified, are relative to the app folder of the drive, i.e. the special folder "approot",
+// instead of the root folder of the drive.
+//
+// This allows apps which are granted the Files.ReadWrite.AppFolder permission only to work entirely
+// within their own folder, e.g. client.AppFolder().DriveItems.GetByPath(ctx, "", "settings/config.json").
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/special-folders-appfolder?view=odsp-graph-online
+func (c *Client) AppFolder() *Client {
+	clone := *c
+	clone.rootURL = "special/" + AppRoot.toString()
+	clone.initialize()
+
+	return &clone
+}
 
+// initialize points all the services of the client to the client itself.
+func (c *Client) initialize() {
 	c.common.client = c
 
 	c.User = (*UserService)(&c.common)
@@ -65,8 +135,50 @@ func NewClient(httpClient *http.Client) *Client {
 	c.DriveSearch = (*DriveSearchService)(&c.common)
 	c.DriveAsyncJob = (*DriveAsyncJobService)(&c.common)
 	c.DrivePermissions = (*PermissionService)(&c.common)
+	c.Shares = (*SharesService)(&c.common)
+	c.Subscriptions = (*SubscriptionsService)(&c.common)
+	c.Search = (*MicrosoftSearchService)(&c.common)
+	c.Sites = (*SitesService)(&c.common)
+	c.Groups = (*GroupsService)(&c.common)
+	c.Bundles = (*BundlesService)(&c.common)
+}
 
-	return c
+// driveURL returns the relative URL of the drive with the given ID.
+// If driveId is empty, it returns the relative URL of the default drive of the user of the client.
+func (c *Client) driveURL(driveId string) string {
+	if driveId == "" {
+		return c.userURL + "/drive"
+	}
+
+	return "drives/" + url.PathEscape(driveId)
+}
+
+// itemPathURL returns the relative URL of the item at the given path, which is relative to the root
+// folder of the client, followed by the given action, e.g. "children" or "content", if any.
+// If itemPath is empty, it returns the relative URL of the root folder of the client.
+func (c *Client) itemPathURL(driveId string, itemPath string, action string) string {
+	apiURL := c.driveURL(driveId) + "/" + c.rootURL
+
+	itemPath = strings.Trim(itemPath, "/")
+	if itemPath == "" {
+		if action != "" {
+			apiURL += "/" + action
+		}
+
+		return apiURL
+	}
+
+	var escapedSegments []string
+	for _, segment := range strings.Split(itemPath, "/") {
+		escapedSegments = append(escapedSegments, url.PathEscape(segment))
+	}
+
+	apiURL += ":/" + strings.Join(escapedSegments, "/")
+	if action != "" {
+		apiURL += ":/" + action
+	}
+
+	return apiURL
 }
 
 // NewRequest creates an API request. A relative URL can be provided in relativeURL,
@@ -152,19 +264,11 @@ func (c *Client) NewSessionFileUploadRequest(absoluteUrl string, grandOffset, gr
 
 }
 
-// NewRequest creates an API request to OneDrive API directly with an absolute URL.
+// NewRequestToOneDrive creates an API request to OneDrive API directly with an absolute URL, such as
+// the monitor URL of an async job. The URL must be an HTTPS URL pointing to one of the MonitorHosts.
 func (c *Client) NewRequestToOneDrive(method, absoluteUrl string, body interface{}) (*http.Request, error) {
-	if !strings.HasPrefix(absoluteUrl, oneDriveBaseUrl) && !strings.HasPrefix(absoluteUrl, "/test-onedrive-api") {
-		return nil, fmt.Errorf("The given URL %q is not a OneDrive API URL.", c.BaseURL)
-	}
-
-	if strings.HasPrefix(absoluteUrl, "/test-onedrive-api") {
-		apiUrl, err := c.BaseURL.Parse(absoluteUrl)
-		if err != nil {
-			return nil, err
-		}
-
-		absoluteUrl = apiUrl.String()
+	if !c.isMonitorURL(absoluteUrl) {
+		return nil, fmt.Errorf("The given URL %q is not a OneDrive API URL.", absoluteUrl)
 	}
 
 	if body != nil {
@@ -186,14 +290,93 @@ func (c *Client) NewRequestToOneDrive(method, absoluteUrl string, body interface
 	return req, err
 }
 
+// isMonitorURL reports whether the given URL is an HTTPS URL pointing to one of the MonitorHosts, or a URL
+// with one of the origins in the MonitorHosts.
+func (c *Client) isMonitorURL(absoluteUrl string) bool {
+	apiUrl, err := url.Parse(absoluteUrl)
+	if err != nil {
+		return false
+	}
+
+	if apiUrl.User != nil || apiUrl.Opaque != "" || apiUrl.Host == "" {
+		return false
+	}
+
+	for _, allowedOrigin := range c.MonitorHosts {
+		if !strings.Contains(allowedOrigin, "://") {
+			continue
+		}
+
+		if originUrl, err := url.Parse(allowedOrigin); err == nil &&
+			strings.EqualFold(apiUrl.Scheme, originUrl.Scheme) && strings.EqualFold(apiUrl.Host, originUrl.Host) {
+			return true
+		}
+	}
+
+	if apiUrl.Scheme != "https" {
+		return false
+	}
+
+	if port := apiUrl.Port(); port != "" && port != "443" {
+		return false
+	}
+
+	host := strings.ToLower(apiUrl.Hostname())
+	if host == "" {
+		return false
+	}
+
+	for _, allowedHost := range c.MonitorHosts {
+		allowedHost = strings.ToLower(allowedHost)
+
+		if strings.HasPrefix(allowedHost, "*.") {
+			domain := allowedHost[1:]
+			if strings.HasSuffix(host, domain) && len(host) > len(domain) {
+				return true
+			}
+		} else if host == allowedHost {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAPIURL reports whether the given URL is either relative, or an absolute URL pointing to the host of the BaseURL,
+// e.g. the next link of a page of a collection, so that the token of the client is only sent to the API.
+func (c *Client) isAPIURL(apiURL string) bool {
+	parsedUrl, err := url.Parse(apiURL)
+	if err != nil {
+		return false
+	}
+
+	if !parsedUrl.IsAbs() && parsedUrl.Host == "" {
+		return true
+	}
+
+	return parsedUrl.User == nil && strings.EqualFold(parsedUrl.Scheme, c.BaseURL.Scheme) &&
+		strings.EqualFold(parsedUrl.Host, c.BaseURL.Host)
+}
+
 // Do sends an API request and returns the API response. The API response is
 // JSON decoded and stored in the value pointed to by target, or returned as an
-// error if an API error has occurred.
+// *Error if an API error has occurred.
+//
+// The metadata of the response is stored in the Response of the context, if any. See WithResponse.
 func (c *Client) Do(ctx context.Context, req *http.Request, isUsingPlainHttpClient bool, target interface{}) error {
+	_, err := c.do(ctx, req, isUsingPlainHttpClient, target)
+
+	return err
+}
+
+// do sends an API request just like Do, but also returns the HTTP response, whose body has
+// already been read and closed, so that the callers can make use of the response headers.
+func (c *Client) do(ctx context.Context, req *http.Request, isUsingPlainHttpClient bool, target interface{}) (*http.Response, error) {
 	if ctx == nil {
-		return errors.New("context must be non-nil")
+		return nil, errors.New("context must be non-nil")
 	}
 	req = req.WithContext(ctx)
+	setClientRequestId(ctx, req)
 
 	var err error
 	var resp *http.Response
@@ -209,7 +392,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, isUsingPlainHttpClie
 		// If we got an error, and the context has been canceled, the error from the context is probably more useful.
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, ctx.Err()
 		default:
 		}
 
@@ -217,18 +400,19 @@ func (c *Client) Do(ctx context.Context, req *http.Request, isUsingPlainHttpClie
 		if e, ok := err.(*url.Error); ok {
 			if url, err := url.Parse(e.URL); err == nil {
 				e.URL = sanitizeURL(url).String()
-				return e
+				return nil, e
 			}
 		}
 
-		return err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
+	recordResponse(ctx, resp)
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return resp, err
 	}
 
 	locationHeader, isLocationHeaderExist := resp.Header["Location"]
@@ -245,23 +429,21 @@ func (c *Client) Do(ctx context.Context, req *http.Request, isUsingPlainHttpClie
 
 		var oneDriveError *ErrorResponse
 		if err = json.NewDecoder(responseBodyReader).Decode(&oneDriveError); err != nil {
-			return err
+			return resp, err
 		}
 
 		if oneDriveError.Error != nil {
-			if oneDriveError.Error.InnerError != nil {
-				return errors.New(oneDriveError.Error.Code + " - " + oneDriveError.Error.Message + " (" + oneDriveError.Error.InnerError.Date + ")")
-			}
-
-			return errors.New(oneDriveError.Error.Code + " - " + oneDriveError.Error.Message)
+			return resp, oneDriveError.Error
 		}
 
-		responseBodyReader = bytes.NewReader(responseBody)
-		err = json.NewDecoder(responseBodyReader).Decode(target)
+		if target != nil {
+			responseBodyReader = bytes.NewReader(responseBody)
+			err = json.NewDecoder(responseBodyReader).Decode(target)
+		}
 
 	}
 
-	return err
+	return resp, err
 }
 
 // sanitizeURL redacts the client_secret parameter from the URL which may be exposed to the user.
@@ -0,0 +1,912 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package onedrivemock provides mocks of the services of the onedrive package.
+//
+// Each mock implements one of the service interfaces of the onedrive package, e.g. DriveItems implements
+// onedrive.DriveItemsAPI, with a function field for each method of the service:
+//
+//	driveItems := &onedrivemock.DriveItems{
+//		GetFunc: func(ctx context.Context, itemId string) (*onedrive.DriveItem, error) {
+//			return &onedrive.DriveItem{Id: itemId, Name: "Document.docx"}, nil
+//		},
+//	}
+//
+// The methods whose function field is nil return an error wrapping ErrNotMocked, so that the unexpected calls
+// fail the tests instead of panicking.
+package onedrivemock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// ErrNotMocked is wrapped by the errors returned by the methods of the mocks whose function is not set.
+var ErrNotMocked = errors.New("onedrivemock: method is not mocked")
+
+// The mocks must implement the service interfaces of the onedrive package.
+var (
+	_ onedrive.UserAPI            = (*User)(nil)
+	_ onedrive.DrivesAPI          = (*Drives)(nil)
+	_ onedrive.DriveItemsAPI      = (*DriveItems)(nil)
+	_ onedrive.DriveSearchAPI     = (*DriveSearch)(nil)
+	_ onedrive.DriveAsyncJobAPI   = (*DriveAsyncJob)(nil)
+	_ onedrive.PermissionAPI      = (*Permission)(nil)
+	_ onedrive.SharesAPI          = (*Shares)(nil)
+	_ onedrive.SubscriptionsAPI   = (*Subscriptions)(nil)
+	_ onedrive.MicrosoftSearchAPI = (*MicrosoftSearch)(nil)
+	_ onedrive.SitesAPI           = (*Sites)(nil)
+	_ onedrive.GroupsAPI          = (*Groups)(nil)
+	_ onedrive.BundlesAPI         = (*Bundles)(nil)
+)
+
+// User is a mock of onedrive.UserAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type User struct {
+	GetCurrentUserDetailsFunc func(context.Context) (*onedrive.User, error)
+	GetFunc                   func(context.Context, string) (*onedrive.User, error)
+	ListFunc                  func(context.Context, int) (*onedrive.OneDriveUsersResponse, error)
+	ListNextFunc              func(context.Context, string) (*onedrive.OneDriveUsersResponse, error)
+}
+
+// GetCurrentUserDetails calls GetCurrentUserDetailsFunc.
+func (m *User) GetCurrentUserDetails(ctx context.Context) (*onedrive.User, error) {
+	if m.GetCurrentUserDetailsFunc == nil {
+		return nil, notMocked("User.GetCurrentUserDetails")
+	}
+
+	return m.GetCurrentUserDetailsFunc(ctx)
+}
+
+// Get calls GetFunc.
+func (m *User) Get(ctx context.Context, userIdOrUPN string) (*onedrive.User, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("User.Get")
+	}
+
+	return m.GetFunc(ctx, userIdOrUPN)
+}
+
+// List calls ListFunc.
+func (m *User) List(ctx context.Context, pageSize int) (*onedrive.OneDriveUsersResponse, error) {
+	if m.ListFunc == nil {
+		return nil, notMocked("User.List")
+	}
+
+	return m.ListFunc(ctx, pageSize)
+}
+
+// ListNext calls ListNextFunc.
+func (m *User) ListNext(ctx context.Context, nextLink string) (*onedrive.OneDriveUsersResponse, error) {
+	if m.ListNextFunc == nil {
+		return nil, notMocked("User.ListNext")
+	}
+
+	return m.ListNextFunc(ctx, nextLink)
+}
+
+// Drives is a mock of onedrive.DrivesAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Drives struct {
+	GetFunc            func(context.Context, string) (*onedrive.Drive, error)
+	CheckQuotaFunc     func(context.Context, string, int64) error
+	GetUserDriveFunc   func(context.Context, string) (*onedrive.Drive, error)
+	ListUserDrivesFunc func(context.Context, string) (*onedrive.OneDriveDrivesResponse, error)
+	ListFunc           func(context.Context) (*onedrive.OneDriveDrivesResponse, error)
+	SharedWithMeFunc   func(context.Context) (*onedrive.OneDriveDriveItemsResponse, error)
+	RecentFunc         func(context.Context) (*onedrive.OneDriveDriveItemsResponse, error)
+}
+
+// Get calls GetFunc.
+func (m *Drives) Get(ctx context.Context, driveId string) (*onedrive.Drive, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("Drives.Get")
+	}
+
+	return m.GetFunc(ctx, driveId)
+}
+
+// CheckQuota calls CheckQuotaFunc.
+func (m *Drives) CheckQuota(ctx context.Context, driveId string, size int64) error {
+	if m.CheckQuotaFunc == nil {
+		return notMocked("Drives.CheckQuota")
+	}
+
+	return m.CheckQuotaFunc(ctx, driveId, size)
+}
+
+// GetUserDrive calls GetUserDriveFunc.
+func (m *Drives) GetUserDrive(ctx context.Context, userIdOrUPN string) (*onedrive.Drive, error) {
+	if m.GetUserDriveFunc == nil {
+		return nil, notMocked("Drives.GetUserDrive")
+	}
+
+	return m.GetUserDriveFunc(ctx, userIdOrUPN)
+}
+
+// ListUserDrives calls ListUserDrivesFunc.
+func (m *Drives) ListUserDrives(ctx context.Context, userIdOrUPN string) (*onedrive.OneDriveDrivesResponse, error) {
+	if m.ListUserDrivesFunc == nil {
+		return nil, notMocked("Drives.ListUserDrives")
+	}
+
+	return m.ListUserDrivesFunc(ctx, userIdOrUPN)
+}
+
+// List calls ListFunc.
+func (m *Drives) List(ctx context.Context) (*onedrive.OneDriveDrivesResponse, error) {
+	if m.ListFunc == nil {
+		return nil, notMocked("Drives.List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+// SharedWithMe calls SharedWithMeFunc.
+func (m *Drives) SharedWithMe(ctx context.Context) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.SharedWithMeFunc == nil {
+		return nil, notMocked("Drives.SharedWithMe")
+	}
+
+	return m.SharedWithMeFunc(ctx)
+}
+
+// Recent calls RecentFunc.
+func (m *Drives) Recent(ctx context.Context) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.RecentFunc == nil {
+		return nil, notMocked("Drives.Recent")
+	}
+
+	return m.RecentFunc(ctx)
+}
+
+// DriveItems is a mock of onedrive.DriveItemsAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type DriveItems struct {
+	ListFunc                func(context.Context, string) (*onedrive.OneDriveDriveItemsResponse, error)
+	ListInDriveFunc         func(context.Context, string, string) (*onedrive.OneDriveDriveItemsResponse, error)
+	ListSpecialFunc         func(context.Context, onedrive.DriveSpecialFolder) (*onedrive.OneDriveDriveItemsResponse, error)
+	DeltaFunc               func(context.Context, string, string, string) (*onedrive.OneDriveDeltaResponse, error)
+	GetFunc                 func(context.Context, string) (*onedrive.DriveItem, error)
+	GetInDriveFunc          func(context.Context, string, string) (*onedrive.DriveItem, error)
+	GetSpecialFunc          func(context.Context, onedrive.DriveSpecialFolder) (*onedrive.DriveItem, error)
+	GetByPathFunc           func(context.Context, string, string) (*onedrive.DriveItem, error)
+	ListByPathFunc          func(context.Context, string, string) (*onedrive.OneDriveDriveItemsResponse, error)
+	CreateNewFolderFunc     func(context.Context, string, string, string) (*onedrive.DriveItem, error)
+	CreateFolderByPathFunc  func(context.Context, string, string, string) (*onedrive.DriveItem, error)
+	DeleteFunc              func(context.Context, string, string) error
+	PermanentDeleteFunc     func(context.Context, string, string) error
+	DeleteMultipleFunc      func(context.Context, string, []string, bool) ([]*onedrive.DeleteItemResult, error)
+	RestoreFunc             func(context.Context, string, string, string, string) (*onedrive.DriveItem, error)
+	MoveFunc                func(context.Context, string, string, string) (*onedrive.MoveItemResponse, error)
+	RenameFunc              func(context.Context, string, string, string) (*onedrive.RenameItemResponse, error)
+	CopyFunc                func(context.Context, string, string, string, string, string) (*onedrive.CopyItemResponse, error)
+	CopyAndWaitFunc         func(context.Context, string, string, string, string, string, *onedrive.WaitOptions) (*onedrive.DriveItem, error)
+	UploadNewFileFunc       func(context.Context, string, string, string) (*onedrive.DriveItem, error)
+	UploadNewFileByPathFunc func(context.Context, string, string, string) (*onedrive.DriveItem, error)
+	UploadToReplaceFileFunc func(context.Context, string, string, string) (*onedrive.DriveItem, error)
+	UploadNewFileLargeFunc  func(context.Context, string, string, string, int64) (*onedrive.DriveItem, error)
+	DownloadItemFunc        func(context.Context, *onedrive.DriveItem) ([]byte, error)
+	DownloadStreamFunc      func(context.Context, string, string) (io.ReadCloser, error)
+	DownloadItemAsFunc      func(context.Context, *onedrive.DriveItem, onedrive.DownloadFormat) (io.ReadCloser, error)
+}
+
+// List calls ListFunc.
+func (m *DriveItems) List(ctx context.Context, folderId string) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListFunc == nil {
+		return nil, notMocked("DriveItems.List")
+	}
+
+	return m.ListFunc(ctx, folderId)
+}
+
+// ListInDrive calls ListInDriveFunc.
+func (m *DriveItems) ListInDrive(ctx context.Context, driveId string, folderId string) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListInDriveFunc == nil {
+		return nil, notMocked("DriveItems.ListInDrive")
+	}
+
+	return m.ListInDriveFunc(ctx, driveId, folderId)
+}
+
+// ListSpecial calls ListSpecialFunc.
+func (m *DriveItems) ListSpecial(ctx context.Context, folderName onedrive.DriveSpecialFolder) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListSpecialFunc == nil {
+		return nil, notMocked("DriveItems.ListSpecial")
+	}
+
+	return m.ListSpecialFunc(ctx, folderName)
+}
+
+// Delta calls DeltaFunc.
+func (m *DriveItems) Delta(ctx context.Context, driveId string, folderId string, deltaLink string) (*onedrive.OneDriveDeltaResponse, error) {
+	if m.DeltaFunc == nil {
+		return nil, notMocked("DriveItems.Delta")
+	}
+
+	return m.DeltaFunc(ctx, driveId, folderId, deltaLink)
+}
+
+// Get calls GetFunc.
+func (m *DriveItems) Get(ctx context.Context, itemId string) (*onedrive.DriveItem, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("DriveItems.Get")
+	}
+
+	return m.GetFunc(ctx, itemId)
+}
+
+// GetInDrive calls GetInDriveFunc.
+func (m *DriveItems) GetInDrive(ctx context.Context, driveId string, itemId string) (*onedrive.DriveItem, error) {
+	if m.GetInDriveFunc == nil {
+		return nil, notMocked("DriveItems.GetInDrive")
+	}
+
+	return m.GetInDriveFunc(ctx, driveId, itemId)
+}
+
+// GetSpecial calls GetSpecialFunc.
+func (m *DriveItems) GetSpecial(ctx context.Context, folderName onedrive.DriveSpecialFolder) (*onedrive.DriveItem, error) {
+	if m.GetSpecialFunc == nil {
+		return nil, notMocked("DriveItems.GetSpecial")
+	}
+
+	return m.GetSpecialFunc(ctx, folderName)
+}
+
+// GetByPath calls GetByPathFunc.
+func (m *DriveItems) GetByPath(ctx context.Context, driveId string, itemPath string) (*onedrive.DriveItem, error) {
+	if m.GetByPathFunc == nil {
+		return nil, notMocked("DriveItems.GetByPath")
+	}
+
+	return m.GetByPathFunc(ctx, driveId, itemPath)
+}
+
+// ListByPath calls ListByPathFunc.
+func (m *DriveItems) ListByPath(ctx context.Context, driveId string, folderPath string) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListByPathFunc == nil {
+		return nil, notMocked("DriveItems.ListByPath")
+	}
+
+	return m.ListByPathFunc(ctx, driveId, folderPath)
+}
+
+// CreateNewFolder calls CreateNewFolderFunc.
+func (m *DriveItems) CreateNewFolder(ctx context.Context, driveId string, parentFolderName string, folderName string) (*onedrive.DriveItem, error) {
+	if m.CreateNewFolderFunc == nil {
+		return nil, notMocked("DriveItems.CreateNewFolder")
+	}
+
+	return m.CreateNewFolderFunc(ctx, driveId, parentFolderName, folderName)
+}
+
+// CreateFolderByPath calls CreateFolderByPathFunc.
+func (m *DriveItems) CreateFolderByPath(ctx context.Context, driveId string, parentFolderPath string, folderName string) (*onedrive.DriveItem, error) {
+	if m.CreateFolderByPathFunc == nil {
+		return nil, notMocked("DriveItems.CreateFolderByPath")
+	}
+
+	return m.CreateFolderByPathFunc(ctx, driveId, parentFolderPath, folderName)
+}
+
+// Delete calls DeleteFunc.
+func (m *DriveItems) Delete(ctx context.Context, driveId string, itemId string) error {
+	if m.DeleteFunc == nil {
+		return notMocked("DriveItems.Delete")
+	}
+
+	return m.DeleteFunc(ctx, driveId, itemId)
+}
+
+// PermanentDelete calls PermanentDeleteFunc.
+func (m *DriveItems) PermanentDelete(ctx context.Context, driveId string, itemId string) error {
+	if m.PermanentDeleteFunc == nil {
+		return notMocked("DriveItems.PermanentDelete")
+	}
+
+	return m.PermanentDeleteFunc(ctx, driveId, itemId)
+}
+
+// DeleteMultiple calls DeleteMultipleFunc.
+func (m *DriveItems) DeleteMultiple(ctx context.Context, driveId string, itemIds []string, permanent bool) ([]*onedrive.DeleteItemResult, error) {
+	if m.DeleteMultipleFunc == nil {
+		return nil, notMocked("DriveItems.DeleteMultiple")
+	}
+
+	return m.DeleteMultipleFunc(ctx, driveId, itemIds, permanent)
+}
+
+// Restore calls RestoreFunc.
+func (m *DriveItems) Restore(ctx context.Context, driveId string, itemId string, destinationParentFolderId string, newItemName string) (*onedrive.DriveItem, error) {
+	if m.RestoreFunc == nil {
+		return nil, notMocked("DriveItems.Restore")
+	}
+
+	return m.RestoreFunc(ctx, driveId, itemId, destinationParentFolderId, newItemName)
+}
+
+// Move calls MoveFunc.
+func (m *DriveItems) Move(ctx context.Context, driveId string, itemId string, destinationParentFolderId string) (*onedrive.MoveItemResponse, error) {
+	if m.MoveFunc == nil {
+		return nil, notMocked("DriveItems.Move")
+	}
+
+	return m.MoveFunc(ctx, driveId, itemId, destinationParentFolderId)
+}
+
+// Rename calls RenameFunc.
+func (m *DriveItems) Rename(ctx context.Context, driveId string, itemId string, newItemName string) (*onedrive.RenameItemResponse, error) {
+	if m.RenameFunc == nil {
+		return nil, notMocked("DriveItems.Rename")
+	}
+
+	return m.RenameFunc(ctx, driveId, itemId, newItemName)
+}
+
+// Copy calls CopyFunc.
+func (m *DriveItems) Copy(ctx context.Context, sourceDriveId string, itemId string, destinationDriveId string, destinationFolderId string, newItemName string) (*onedrive.CopyItemResponse, error) {
+	if m.CopyFunc == nil {
+		return nil, notMocked("DriveItems.Copy")
+	}
+
+	return m.CopyFunc(ctx, sourceDriveId, itemId, destinationDriveId, destinationFolderId, newItemName)
+}
+
+// CopyAndWait calls CopyAndWaitFunc.
+func (m *DriveItems) CopyAndWait(ctx context.Context, sourceDriveId string, itemId string, destinationDriveId string, destinationFolderId string, newItemName string, options *onedrive.WaitOptions) (*onedrive.DriveItem, error) {
+	if m.CopyAndWaitFunc == nil {
+		return nil, notMocked("DriveItems.CopyAndWait")
+	}
+
+	return m.CopyAndWaitFunc(ctx, sourceDriveId, itemId, destinationDriveId, destinationFolderId, newItemName, options)
+}
+
+// UploadNewFile calls UploadNewFileFunc.
+func (m *DriveItems) UploadNewFile(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string) (*onedrive.DriveItem, error) {
+	if m.UploadNewFileFunc == nil {
+		return nil, notMocked("DriveItems.UploadNewFile")
+	}
+
+	return m.UploadNewFileFunc(ctx, driveId, destinationParentFolderId, localFilePath)
+}
+
+// UploadNewFileByPath calls UploadNewFileByPathFunc.
+func (m *DriveItems) UploadNewFileByPath(ctx context.Context, driveId string, destinationFolderPath string, localFilePath string) (*onedrive.DriveItem, error) {
+	if m.UploadNewFileByPathFunc == nil {
+		return nil, notMocked("DriveItems.UploadNewFileByPath")
+	}
+
+	return m.UploadNewFileByPathFunc(ctx, driveId, destinationFolderPath, localFilePath)
+}
+
+// UploadToReplaceFile calls UploadToReplaceFileFunc.
+func (m *DriveItems) UploadToReplaceFile(ctx context.Context, driveId string, localFilePath string, itemId string) (*onedrive.DriveItem, error) {
+	if m.UploadToReplaceFileFunc == nil {
+		return nil, notMocked("DriveItems.UploadToReplaceFile")
+	}
+
+	return m.UploadToReplaceFileFunc(ctx, driveId, localFilePath, itemId)
+}
+
+// UploadNewFileLarge calls UploadNewFileLargeFunc.
+func (m *DriveItems) UploadNewFileLarge(ctx context.Context, driveId string, destinationParentFolderId string, localFilePath string, sizePerSplit int64) (*onedrive.DriveItem, error) {
+	if m.UploadNewFileLargeFunc == nil {
+		return nil, notMocked("DriveItems.UploadNewFileLarge")
+	}
+
+	return m.UploadNewFileLargeFunc(ctx, driveId, destinationParentFolderId, localFilePath, sizePerSplit)
+}
+
+// DownloadItem calls DownloadItemFunc.
+func (m *DriveItems) DownloadItem(ctx context.Context, item *onedrive.DriveItem) ([]byte, error) {
+	if m.DownloadItemFunc == nil {
+		return nil, notMocked("DriveItems.DownloadItem")
+	}
+
+	return m.DownloadItemFunc(ctx, item)
+}
+
+// DownloadStream calls DownloadStreamFunc.
+func (m *DriveItems) DownloadStream(ctx context.Context, driveId string, itemId string) (io.ReadCloser, error) {
+	if m.DownloadStreamFunc == nil {
+		return nil, notMocked("DriveItems.DownloadStream")
+	}
+
+	return m.DownloadStreamFunc(ctx, driveId, itemId)
+}
+
+// DownloadItemAs calls DownloadItemAsFunc.
+func (m *DriveItems) DownloadItemAs(ctx context.Context, item *onedrive.DriveItem, format onedrive.DownloadFormat) (io.ReadCloser, error) {
+	if m.DownloadItemAsFunc == nil {
+		return nil, notMocked("DriveItems.DownloadItemAs")
+	}
+
+	return m.DownloadItemAsFunc(ctx, item, format)
+}
+
+// DriveSearch is a mock of onedrive.DriveSearchAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type DriveSearch struct {
+	SearchFunc               func(context.Context, string) (*onedrive.OneDriveDriveSearchResponse, error)
+	SearchInDriveFunc        func(context.Context, string, string, *onedrive.SearchOptions) (*onedrive.OneDriveDriveSearchResponse, error)
+	SearchInFolderFunc       func(context.Context, string, string, string, *onedrive.SearchOptions) (*onedrive.OneDriveDriveSearchResponse, error)
+	SearchAllFunc            func(context.Context, string) (*onedrive.OneDriveDriveSearchResponse, error)
+	SearchAllWithOptionsFunc func(context.Context, string, *onedrive.SearchOptions) (*onedrive.OneDriveDriveSearchResponse, error)
+}
+
+// Search calls SearchFunc.
+func (m *DriveSearch) Search(ctx context.Context, query string) (*onedrive.OneDriveDriveSearchResponse, error) {
+	if m.SearchFunc == nil {
+		return nil, notMocked("DriveSearch.Search")
+	}
+
+	return m.SearchFunc(ctx, query)
+}
+
+// SearchInDrive calls SearchInDriveFunc.
+func (m *DriveSearch) SearchInDrive(ctx context.Context, driveId string, query string, options *onedrive.SearchOptions) (*onedrive.OneDriveDriveSearchResponse, error) {
+	if m.SearchInDriveFunc == nil {
+		return nil, notMocked("DriveSearch.SearchInDrive")
+	}
+
+	return m.SearchInDriveFunc(ctx, driveId, query, options)
+}
+
+// SearchInFolder calls SearchInFolderFunc.
+func (m *DriveSearch) SearchInFolder(ctx context.Context, driveId string, folderId string, query string, options *onedrive.SearchOptions) (*onedrive.OneDriveDriveSearchResponse, error) {
+	if m.SearchInFolderFunc == nil {
+		return nil, notMocked("DriveSearch.SearchInFolder")
+	}
+
+	return m.SearchInFolderFunc(ctx, driveId, folderId, query, options)
+}
+
+// SearchAll calls SearchAllFunc.
+func (m *DriveSearch) SearchAll(ctx context.Context, query string) (*onedrive.OneDriveDriveSearchResponse, error) {
+	if m.SearchAllFunc == nil {
+		return nil, notMocked("DriveSearch.SearchAll")
+	}
+
+	return m.SearchAllFunc(ctx, query)
+}
+
+// SearchAllWithOptions calls SearchAllWithOptionsFunc.
+func (m *DriveSearch) SearchAllWithOptions(ctx context.Context, query string, options *onedrive.SearchOptions) (*onedrive.OneDriveDriveSearchResponse, error) {
+	if m.SearchAllWithOptionsFunc == nil {
+		return nil, notMocked("DriveSearch.SearchAllWithOptions")
+	}
+
+	return m.SearchAllWithOptionsFunc(ctx, query, options)
+}
+
+// DriveAsyncJob is a mock of onedrive.DriveAsyncJobAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type DriveAsyncJob struct {
+	MonitorFunc func(context.Context, string) (*onedrive.OneDriveAsyncJobMonitorResponse, error)
+	WaitFunc    func(context.Context, string, string, *onedrive.WaitOptions) (*onedrive.DriveItem, error)
+}
+
+// Monitor calls MonitorFunc.
+func (m *DriveAsyncJob) Monitor(ctx context.Context, monitorUrl string) (*onedrive.OneDriveAsyncJobMonitorResponse, error) {
+	if m.MonitorFunc == nil {
+		return nil, notMocked("DriveAsyncJob.Monitor")
+	}
+
+	return m.MonitorFunc(ctx, monitorUrl)
+}
+
+// Wait calls WaitFunc.
+func (m *DriveAsyncJob) Wait(ctx context.Context, driveId string, monitorUrl string, options *onedrive.WaitOptions) (*onedrive.DriveItem, error) {
+	if m.WaitFunc == nil {
+		return nil, notMocked("DriveAsyncJob.Wait")
+	}
+
+	return m.WaitFunc(ctx, driveId, monitorUrl, options)
+}
+
+// Permission is a mock of onedrive.PermissionAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Permission struct {
+	CreateShareLinkFunc            func(context.Context, string, onedrive.ShareLinkType, onedrive.ShareLinkScope) (*onedrive.Permission, error)
+	CreateShareLinkWithOptionsFunc func(context.Context, string, string, onedrive.ShareLinkType, onedrive.ShareLinkScope, *onedrive.ShareLinkOptions) (*onedrive.Permission, error)
+	ListFunc                       func(context.Context, string) ([]onedrive.Permission, error)
+	ListInDriveFunc                func(context.Context, string, string) ([]onedrive.Permission, error)
+	DeleteFunc                     func(context.Context, string, string, string) error
+	InviteFunc                     func(context.Context, string, string, *onedrive.Invitation) ([]onedrive.Permission, error)
+	UpdateFunc                     func(context.Context, string, string, string, *onedrive.PermissionUpdate) (*onedrive.Permission, error)
+}
+
+// CreateShareLink calls CreateShareLinkFunc.
+func (m *Permission) CreateShareLink(ctx context.Context, itemId string, permissionType onedrive.ShareLinkType, permissionScope onedrive.ShareLinkScope) (*onedrive.Permission, error) {
+	if m.CreateShareLinkFunc == nil {
+		return nil, notMocked("Permission.CreateShareLink")
+	}
+
+	return m.CreateShareLinkFunc(ctx, itemId, permissionType, permissionScope)
+}
+
+// CreateShareLinkWithOptions calls CreateShareLinkWithOptionsFunc.
+func (m *Permission) CreateShareLinkWithOptions(ctx context.Context, driveId string, itemId string, permissionType onedrive.ShareLinkType, permissionScope onedrive.ShareLinkScope, options *onedrive.ShareLinkOptions) (*onedrive.Permission, error) {
+	if m.CreateShareLinkWithOptionsFunc == nil {
+		return nil, notMocked("Permission.CreateShareLinkWithOptions")
+	}
+
+	return m.CreateShareLinkWithOptionsFunc(ctx, driveId, itemId, permissionType, permissionScope, options)
+}
+
+// List calls ListFunc.
+func (m *Permission) List(ctx context.Context, itemId string) ([]onedrive.Permission, error) {
+	if m.ListFunc == nil {
+		return nil, notMocked("Permission.List")
+	}
+
+	return m.ListFunc(ctx, itemId)
+}
+
+// ListInDrive calls ListInDriveFunc.
+func (m *Permission) ListInDrive(ctx context.Context, driveId string, itemId string) ([]onedrive.Permission, error) {
+	if m.ListInDriveFunc == nil {
+		return nil, notMocked("Permission.ListInDrive")
+	}
+
+	return m.ListInDriveFunc(ctx, driveId, itemId)
+}
+
+// Delete calls DeleteFunc.
+func (m *Permission) Delete(ctx context.Context, driveId string, itemId string, permissionId string) error {
+	if m.DeleteFunc == nil {
+		return notMocked("Permission.Delete")
+	}
+
+	return m.DeleteFunc(ctx, driveId, itemId, permissionId)
+}
+
+// Invite calls InviteFunc.
+func (m *Permission) Invite(ctx context.Context, driveId string, itemId string, invitation *onedrive.Invitation) ([]onedrive.Permission, error) {
+	if m.InviteFunc == nil {
+		return nil, notMocked("Permission.Invite")
+	}
+
+	return m.InviteFunc(ctx, driveId, itemId, invitation)
+}
+
+// Update calls UpdateFunc.
+func (m *Permission) Update(ctx context.Context, driveId string, itemId string, permissionId string, update *onedrive.PermissionUpdate) (*onedrive.Permission, error) {
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Permission.Update")
+	}
+
+	return m.UpdateFunc(ctx, driveId, itemId, permissionId, update)
+}
+
+// Shares is a mock of onedrive.SharesAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Shares struct {
+	GetFunc          func(context.Context, string, bool) (*onedrive.SharedDriveItem, error)
+	GetDriveItemFunc func(context.Context, string, bool) (*onedrive.DriveItem, error)
+	GetRootFunc      func(context.Context, string, bool) (*onedrive.DriveItem, error)
+	ListChildrenFunc func(context.Context, string) (*onedrive.OneDriveDriveItemsResponse, error)
+	DownloadFunc     func(context.Context, string) ([]byte, error)
+}
+
+// Get calls GetFunc.
+func (m *Shares) Get(ctx context.Context, shareIdOrURL string, redeem bool) (*onedrive.SharedDriveItem, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("Shares.Get")
+	}
+
+	return m.GetFunc(ctx, shareIdOrURL, redeem)
+}
+
+// GetDriveItem calls GetDriveItemFunc.
+func (m *Shares) GetDriveItem(ctx context.Context, shareIdOrURL string, redeem bool) (*onedrive.DriveItem, error) {
+	if m.GetDriveItemFunc == nil {
+		return nil, notMocked("Shares.GetDriveItem")
+	}
+
+	return m.GetDriveItemFunc(ctx, shareIdOrURL, redeem)
+}
+
+// GetRoot calls GetRootFunc.
+func (m *Shares) GetRoot(ctx context.Context, shareIdOrURL string, redeem bool) (*onedrive.DriveItem, error) {
+	if m.GetRootFunc == nil {
+		return nil, notMocked("Shares.GetRoot")
+	}
+
+	return m.GetRootFunc(ctx, shareIdOrURL, redeem)
+}
+
+// ListChildren calls ListChildrenFunc.
+func (m *Shares) ListChildren(ctx context.Context, shareIdOrURL string) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListChildrenFunc == nil {
+		return nil, notMocked("Shares.ListChildren")
+	}
+
+	return m.ListChildrenFunc(ctx, shareIdOrURL)
+}
+
+// Download calls DownloadFunc.
+func (m *Shares) Download(ctx context.Context, shareIdOrURL string) ([]byte, error) {
+	if m.DownloadFunc == nil {
+		return nil, notMocked("Shares.Download")
+	}
+
+	return m.DownloadFunc(ctx, shareIdOrURL)
+}
+
+// Subscriptions is a mock of onedrive.SubscriptionsAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Subscriptions struct {
+	CreateFunc func(context.Context, string, string, string, time.Time) (*onedrive.Subscription, error)
+	RenewFunc  func(context.Context, string, time.Time) (*onedrive.Subscription, error)
+	GetFunc    func(context.Context, string) (*onedrive.Subscription, error)
+	ListFunc   func(context.Context) (*onedrive.OneDriveSubscriptionsResponse, error)
+	DeleteFunc func(context.Context, string) error
+}
+
+// Create calls CreateFunc.
+func (m *Subscriptions) Create(ctx context.Context, driveId string, notificationUrl string, clientState string, expirationDateTime time.Time) (*onedrive.Subscription, error) {
+	if m.CreateFunc == nil {
+		return nil, notMocked("Subscriptions.Create")
+	}
+
+	return m.CreateFunc(ctx, driveId, notificationUrl, clientState, expirationDateTime)
+}
+
+// Renew calls RenewFunc.
+func (m *Subscriptions) Renew(ctx context.Context, subscriptionId string, expirationDateTime time.Time) (*onedrive.Subscription, error) {
+	if m.RenewFunc == nil {
+		return nil, notMocked("Subscriptions.Renew")
+	}
+
+	return m.RenewFunc(ctx, subscriptionId, expirationDateTime)
+}
+
+// Get calls GetFunc.
+func (m *Subscriptions) Get(ctx context.Context, subscriptionId string) (*onedrive.Subscription, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("Subscriptions.Get")
+	}
+
+	return m.GetFunc(ctx, subscriptionId)
+}
+
+// List calls ListFunc.
+func (m *Subscriptions) List(ctx context.Context) (*onedrive.OneDriveSubscriptionsResponse, error) {
+	if m.ListFunc == nil {
+		return nil, notMocked("Subscriptions.List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+// Delete calls DeleteFunc.
+func (m *Subscriptions) Delete(ctx context.Context, subscriptionId string) error {
+	if m.DeleteFunc == nil {
+		return notMocked("Subscriptions.Delete")
+	}
+
+	return m.DeleteFunc(ctx, subscriptionId)
+}
+
+// MicrosoftSearch is a mock of onedrive.MicrosoftSearchAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type MicrosoftSearch struct {
+	QueryFunc func(context.Context, string, *onedrive.SearchQueryOptions) (*onedrive.SearchResponse, error)
+}
+
+// Query calls QueryFunc.
+func (m *MicrosoftSearch) Query(ctx context.Context, queryString string, options *onedrive.SearchQueryOptions) (*onedrive.SearchResponse, error) {
+	if m.QueryFunc == nil {
+		return nil, notMocked("MicrosoftSearch.Query")
+	}
+
+	return m.QueryFunc(ctx, queryString, options)
+}
+
+// Sites is a mock of onedrive.SitesAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Sites struct {
+	GetFunc             func(context.Context, string) (*onedrive.Site, error)
+	GetRootFunc         func(context.Context) (*onedrive.Site, error)
+	GetByPathFunc       func(context.Context, string, string) (*onedrive.Site, error)
+	SearchFunc          func(context.Context, string) (*onedrive.OneDriveSitesResponse, error)
+	ListFollowedFunc    func(context.Context) (*onedrive.OneDriveSitesResponse, error)
+	GetDefaultDriveFunc func(context.Context, string) (*onedrive.Drive, error)
+	ListDrivesFunc      func(context.Context, string) (*onedrive.OneDriveDrivesResponse, error)
+}
+
+// Get calls GetFunc.
+func (m *Sites) Get(ctx context.Context, siteId string) (*onedrive.Site, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("Sites.Get")
+	}
+
+	return m.GetFunc(ctx, siteId)
+}
+
+// GetRoot calls GetRootFunc.
+func (m *Sites) GetRoot(ctx context.Context) (*onedrive.Site, error) {
+	if m.GetRootFunc == nil {
+		return nil, notMocked("Sites.GetRoot")
+	}
+
+	return m.GetRootFunc(ctx)
+}
+
+// GetByPath calls GetByPathFunc.
+func (m *Sites) GetByPath(ctx context.Context, hostname string, serverRelativePath string) (*onedrive.Site, error) {
+	if m.GetByPathFunc == nil {
+		return nil, notMocked("Sites.GetByPath")
+	}
+
+	return m.GetByPathFunc(ctx, hostname, serverRelativePath)
+}
+
+// Search calls SearchFunc.
+func (m *Sites) Search(ctx context.Context, query string) (*onedrive.OneDriveSitesResponse, error) {
+	if m.SearchFunc == nil {
+		return nil, notMocked("Sites.Search")
+	}
+
+	return m.SearchFunc(ctx, query)
+}
+
+// ListFollowed calls ListFollowedFunc.
+func (m *Sites) ListFollowed(ctx context.Context) (*onedrive.OneDriveSitesResponse, error) {
+	if m.ListFollowedFunc == nil {
+		return nil, notMocked("Sites.ListFollowed")
+	}
+
+	return m.ListFollowedFunc(ctx)
+}
+
+// GetDefaultDrive calls GetDefaultDriveFunc.
+func (m *Sites) GetDefaultDrive(ctx context.Context, siteId string) (*onedrive.Drive, error) {
+	if m.GetDefaultDriveFunc == nil {
+		return nil, notMocked("Sites.GetDefaultDrive")
+	}
+
+	return m.GetDefaultDriveFunc(ctx, siteId)
+}
+
+// ListDrives calls ListDrivesFunc.
+func (m *Sites) ListDrives(ctx context.Context, siteId string) (*onedrive.OneDriveDrivesResponse, error) {
+	if m.ListDrivesFunc == nil {
+		return nil, notMocked("Sites.ListDrives")
+	}
+
+	return m.ListDrivesFunc(ctx, siteId)
+}
+
+// Groups is a mock of onedrive.GroupsAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Groups struct {
+	GetDefaultDriveFunc       func(context.Context, string) (*onedrive.Drive, error)
+	ListDrivesFunc            func(context.Context, string) (*onedrive.OneDriveDrivesResponse, error)
+	GetChannelFilesFolderFunc func(context.Context, string, string) (*onedrive.DriveItem, error)
+}
+
+// GetDefaultDrive calls GetDefaultDriveFunc.
+func (m *Groups) GetDefaultDrive(ctx context.Context, groupId string) (*onedrive.Drive, error) {
+	if m.GetDefaultDriveFunc == nil {
+		return nil, notMocked("Groups.GetDefaultDrive")
+	}
+
+	return m.GetDefaultDriveFunc(ctx, groupId)
+}
+
+// ListDrives calls ListDrivesFunc.
+func (m *Groups) ListDrives(ctx context.Context, groupId string) (*onedrive.OneDriveDrivesResponse, error) {
+	if m.ListDrivesFunc == nil {
+		return nil, notMocked("Groups.ListDrives")
+	}
+
+	return m.ListDrivesFunc(ctx, groupId)
+}
+
+// GetChannelFilesFolder calls GetChannelFilesFolderFunc.
+func (m *Groups) GetChannelFilesFolder(ctx context.Context, teamId string, channelId string) (*onedrive.DriveItem, error) {
+	if m.GetChannelFilesFolderFunc == nil {
+		return nil, notMocked("Groups.GetChannelFilesFolder")
+	}
+
+	return m.GetChannelFilesFolderFunc(ctx, teamId, channelId)
+}
+
+// Bundles is a mock of onedrive.BundlesAPI. Each method calls the function in the field named after the method with
+// the "Func" suffix, or returns ErrNotMocked if the field is nil.
+type Bundles struct {
+	CreateFunc       func(context.Context, string, []string, bool) (*onedrive.DriveItem, error)
+	GetFunc          func(context.Context, string) (*onedrive.DriveItem, error)
+	ListFunc         func(context.Context, bool) (*onedrive.OneDriveDriveItemsResponse, error)
+	ListChildrenFunc func(context.Context, string) (*onedrive.OneDriveDriveItemsResponse, error)
+	AddItemFunc      func(context.Context, string, string) error
+	RemoveItemFunc   func(context.Context, string, string) error
+	RenameFunc       func(context.Context, string, string) (*onedrive.DriveItem, error)
+	DeleteFunc       func(context.Context, string) error
+}
+
+// Create calls CreateFunc.
+func (m *Bundles) Create(ctx context.Context, name string, itemIds []string, isAlbum bool) (*onedrive.DriveItem, error) {
+	if m.CreateFunc == nil {
+		return nil, notMocked("Bundles.Create")
+	}
+
+	return m.CreateFunc(ctx, name, itemIds, isAlbum)
+}
+
+// Get calls GetFunc.
+func (m *Bundles) Get(ctx context.Context, bundleId string) (*onedrive.DriveItem, error) {
+	if m.GetFunc == nil {
+		return nil, notMocked("Bundles.Get")
+	}
+
+	return m.GetFunc(ctx, bundleId)
+}
+
+// List calls ListFunc.
+func (m *Bundles) List(ctx context.Context, albumsOnly bool) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListFunc == nil {
+		return nil, notMocked("Bundles.List")
+	}
+
+	return m.ListFunc(ctx, albumsOnly)
+}
+
+// ListChildren calls ListChildrenFunc.
+func (m *Bundles) ListChildren(ctx context.Context, bundleId string) (*onedrive.OneDriveDriveItemsResponse, error) {
+	if m.ListChildrenFunc == nil {
+		return nil, notMocked("Bundles.ListChildren")
+	}
+
+	return m.ListChildrenFunc(ctx, bundleId)
+}
+
+// AddItem calls AddItemFunc.
+func (m *Bundles) AddItem(ctx context.Context, bundleId string, itemId string) error {
+	if m.AddItemFunc == nil {
+		return notMocked("Bundles.AddItem")
+	}
+
+	return m.AddItemFunc(ctx, bundleId, itemId)
+}
+
+// RemoveItem calls RemoveItemFunc.
+func (m *Bundles) RemoveItem(ctx context.Context, bundleId string, itemId string) error {
+	if m.RemoveItemFunc == nil {
+		return notMocked("Bundles.RemoveItem")
+	}
+
+	return m.RemoveItemFunc(ctx, bundleId, itemId)
+}
+
+// Rename calls RenameFunc.
+func (m *Bundles) Rename(ctx context.Context, bundleId string, newName string) (*onedrive.DriveItem, error) {
+	if m.RenameFunc == nil {
+		return nil, notMocked("Bundles.Rename")
+	}
+
+	return m.RenameFunc(ctx, bundleId, newName)
+}
+
+// Delete calls DeleteFunc.
+func (m *Bundles) Delete(ctx context.Context, bundleId string) error {
+	if m.DeleteFunc == nil {
+		return notMocked("Bundles.Delete")
+	}
+
+	return m.DeleteFunc(ctx, bundleId)
+}
+
+// notMocked returns the error of a method which is not mocked.
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
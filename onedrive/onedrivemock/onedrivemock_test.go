@@ -0,0 +1,96 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivemock
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// dryRunDriveItems is a decorator of the drive items which skips the deletions and records them instead.
+type dryRunDriveItems struct {
+	onedrive.DriveItemsAPI
+
+	deleted []string
+}
+
+func (d *dryRunDriveItems) Delete(ctx context.Context, driveId string, itemId string) error {
+	d.deleted = append(d.deleted, itemId)
+	return nil
+}
+
+func TestDriveItems_Mocked(t *testing.T) {
+	var requestedId string
+	driveItems := &DriveItems{
+		GetFunc: func(ctx context.Context, itemId string) (*onedrive.DriveItem, error) {
+			requestedId = itemId
+			return &onedrive.DriveItem{Id: itemId, Name: "Document.docx"}, nil
+		},
+	}
+
+	var api onedrive.DriveItemsAPI = driveItems
+
+	item, err := api.Get(context.Background(), "item-1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if requestedId != "item-1" || item.Name != "Document.docx" {
+		t.Errorf("Get returned %+v for %q", item, requestedId)
+	}
+}
+
+func TestDriveItems_NotMocked(t *testing.T) {
+	driveItems := &DriveItems{}
+
+	item, err := driveItems.GetByPath(context.Background(), "", "/Documents")
+	if item != nil {
+		t.Errorf("GetByPath returned %+v, want nil", item)
+	}
+
+	if !errors.Is(err, ErrNotMocked) {
+		t.Fatalf("GetByPath returned error %v, want ErrNotMocked", err)
+	}
+
+	if !strings.Contains(err.Error(), "DriveItems.GetByPath") {
+		t.Errorf("Error %q does not name the method", err.Error())
+	}
+
+	if err := driveItems.Delete(context.Background(), "", "item-1"); !errors.Is(err, ErrNotMocked) {
+		t.Errorf("Delete returned error %v, want ErrNotMocked", err)
+	}
+}
+
+func TestDriveItems_Decorated(t *testing.T) {
+	driveItems := &DriveItems{
+		GetFunc: func(ctx context.Context, itemId string) (*onedrive.DriveItem, error) {
+			return &onedrive.DriveItem{Id: itemId}, nil
+		},
+		DeleteFunc: func(ctx context.Context, driveId string, itemId string) error {
+			t.Errorf("Delete of %q was not skipped by the decorator", itemId)
+			return nil
+		},
+	}
+
+	decorator := &dryRunDriveItems{DriveItemsAPI: driveItems}
+
+	var api onedrive.DriveItemsAPI = decorator
+
+	if item, err := api.Get(context.Background(), "item-1"); err != nil || item.Id != "item-1" {
+		t.Errorf("Get returned %+v, %v", item, err)
+	}
+
+	if err := api.Delete(context.Background(), "", "item-1"); err != nil {
+		t.Errorf("Delete returned error: %v", err)
+	}
+
+	if len(decorator.deleted) != 1 || decorator.deleted[0] != "item-1" {
+		t.Errorf("Deleted items = %v, want [item-1]", decorator.deleted)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+var errResyncRequired = &apiError{http.StatusGone, "resyncRequired", "The delta token is not valid. The changes must be tracked from the beginning again."}
+
+// sortedItems returns all the items, including the deleted ones, in the order they were last changed.
+func (s *Server) sortedItems() []*item {
+	items := make([]*item, 0, len(s.items))
+	for _, it := range s.items {
+		items = append(items, it)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].sequence < items[j].sequence
+	})
+
+	return items
+}
+
+// delta serves the changes of the items in a folder, including the folder itself and the folders below it.
+//
+// The token of the delta links is the sequence number of the last change which has been reported. Without a
+// token, all the existing items are reported as the initial state of the folder, and the token "latest"
+// returns a delta link to the current state without reporting any items. Each page of the changes is limited
+// to the delta page size of the server, and its next link reports the changes up to the same sequence
+// number, so that the pages are consistent even if the items are changed in the meantime.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delta?view=odsp-graph-online
+func (s *Server) delta(w http.ResponseWriter, r *http.Request, folder *item) {
+	query := r.URL.Query()
+	deltaURL := s.server.URL + r.URL.EscapedPath()
+
+	token := query.Get("token")
+	if token == "latest" {
+		writeJSON(w, http.StatusOK, &onedrive.OneDriveDeltaResponse{
+			DriveItems: []*onedrive.DriveItem{},
+			DeltaLink:  deltaURL + "?token=" + strconv.FormatInt(s.sequence, 10),
+		})
+		return
+	}
+
+	var from int64
+	if token != "" {
+		var err error
+		if from, err = strconv.ParseInt(token, 10, 64); err != nil || from < 0 || from > s.sequence {
+			errResyncRequired.write(w)
+			return
+		}
+	}
+
+	until := s.sequence
+	if value := query.Get("until"); value != "" {
+		until, _ = strconv.ParseInt(value, 10, 64)
+	}
+
+	skip, _ := strconv.Atoi(query.Get("skip"))
+
+	var changes []*item
+	for _, it := range s.sortedItems() {
+		if it.sequence <= from || it.sequence > until || !s.isAncestor(folder, it) {
+			continue
+		}
+
+		// The deleted items are only reported when tracking changes after the initial state.
+		if token == "" && !it.exists() {
+			continue
+		}
+
+		changes = append(changes, it)
+	}
+
+	response := &onedrive.OneDriveDeltaResponse{DriveItems: []*onedrive.DriveItem{}}
+	for i := skip; i < len(changes) && i < skip+s.deltaPageSize; i++ {
+		response.DriveItems = append(response.DriveItems, s.snapshot(changes[i]))
+	}
+
+	if skip+s.deltaPageSize < len(changes) {
+		nextQuery := url.Values{
+			"token": {token},
+			"until": {strconv.FormatInt(until, 10)},
+			"skip":  {strconv.Itoa(skip + s.deltaPageSize)},
+		}
+		response.NextLink = deltaURL + "?" + nextQuery.Encode()
+	} else {
+		response.DeltaLink = deltaURL + "?token=" + strconv.FormatInt(until, 10)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
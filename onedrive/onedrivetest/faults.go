@@ -0,0 +1,120 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault describes an error response which the server returns instead of handling the matching requests.
+type Fault struct {
+	// Method is the HTTP method of the matching requests. Empty matches any method.
+	Method string
+
+	// Path is a substring of the unescaped URL path of the matching requests, e.g. "/children" or the
+	// ID of an item. Empty matches any path.
+	Path string
+
+	// StatusCode is the HTTP status code of the error response. Defaults to 500.
+	StatusCode int
+
+	// Code is the error code in the error response. Defaults to "generalException".
+	Code string
+
+	// Message is the error message in the error response.
+	Message string
+
+	// RetryAfter is the delay requested by the Retry-After header of the error response, which is
+	// rounded up to whole seconds. The header is not set if RetryAfter is zero.
+	RetryAfter time.Duration
+
+	// Times is the number of the matching requests which fail. If Times is zero, only the next matching
+	// request fails. If Times is negative, all the matching requests fail until ClearFaults is called.
+	Times int
+}
+
+// InjectFault makes the server return an error response for the requests matching the fault.
+// When a request matches several faults, the one injected first is used.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault.StatusCode == 0 {
+		fault.StatusCode = http.StatusInternalServerError
+	}
+
+	if fault.Code == "" {
+		fault.Code = "generalException"
+	}
+
+	if fault.Message == "" {
+		fault.Message = "An injected error has occurred."
+	}
+
+	if fault.Times == 0 {
+		fault.Times = 1
+	}
+
+	s.faults = append(s.faults, &fault)
+}
+
+// Throttle makes the server reject the given number of the matching requests with 429 Too Many Requests,
+// asking the client to retry after the given delay, just like OneDrive does when the client is throttled.
+// The method and the path are matched as in Fault.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/scan-guidance?view=odsp-graph-online#how-do-i-know-im-being-throttled
+func (s *Server) Throttle(method string, path string, retryAfter time.Duration, times int) {
+	s.InjectFault(Fault{
+		Method:     method,
+		Path:       path,
+		StatusCode: http.StatusTooManyRequests,
+		Code:       "activityLimitReached",
+		Message:    "The request has been throttled.",
+		RetryAfter: retryAfter,
+		Times:      times,
+	})
+}
+
+// ClearFaults removes all the injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// injectFault writes the error response of the first fault matching the request, if any, and reports whether it has done so.
+func (s *Server) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	for i, fault := range s.faults {
+		if fault.Method != "" && !strings.EqualFold(fault.Method, r.Method) {
+			continue
+		}
+
+		if !strings.Contains(r.URL.Path, fault.Path) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		if fault.RetryAfter > 0 {
+			seconds := (fault.RetryAfter + time.Second - 1) / time.Second
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(seconds), 10))
+		}
+
+		writeError(w, fault.StatusCode, fault.Code, fault.Message)
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,578 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// Possible values of the conflict behavior of a request, i.e. what to do when an item with the same name already exists.
+const (
+	conflictFail    = "fail"
+	conflictRename  = "rename"
+	conflictReplace = "replace"
+)
+
+// itemTarget is the item addressed by a request, e.g. "items/{item-id}/children" or "root:/Documents/report.txt:/content".
+type itemTarget struct {
+	item   *item  // nil if the item at the path does not exist.
+	parent *item  // The deepest existing folder on the path if item is nil.
+	action string // e.g. "children", "content" or "permissions/{permission-id}".
+
+	missingSegments []string // The segments of the path below parent if item is nil.
+}
+
+// apiError is an error response of the OneDrive API.
+type apiError struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+var (
+	errItemNotFound   = &apiError{http.StatusNotFound, "itemNotFound", "The resource could not be found."}
+	errNotSupported   = &apiError{http.StatusBadRequest, "invalidRequest", "The request is not supported by the fake OneDrive API."}
+	errMalformedBody  = &apiError{http.StatusBadRequest, "invalidRequest", "The request body is malformed."}
+	errNameConflict   = &apiError{http.StatusConflict, "nameAlreadyExists", "An item with the same name already exists."}
+	errAccessDenied   = &apiError{http.StatusForbidden, "accessDenied", "The operation is not allowed on the root folder."}
+	errQuotaExceeded  = &apiError{http.StatusInsufficientStorage, "quotaLimitReached", "The drive does not have enough space."}
+	errNotAFolder     = &apiError{http.StatusBadRequest, "invalidRequest", "The item is not a folder."}
+	errNotAFile       = &apiError{http.StatusBadRequest, "invalidRequest", "The item is not a file."}
+	errInvalidMove    = &apiError{http.StatusBadRequest, "invalidRequest", "A folder cannot be moved into itself."}
+	errNotRestorable  = &apiError{http.StatusBadRequest, "invalidRequest", "The item is not in the recycle bin."}
+	errInvalidRange   = &apiError{http.StatusRequestedRangeNotSatisfiable, "invalidRange", "The uploaded fragment does not start at the next expected range."}
+	errSessionExpired = &apiError{http.StatusNotFound, "itemNotFound", "The upload session does not exist or has expired."}
+)
+
+func (e *apiError) write(w http.ResponseWriter) {
+	writeError(w, e.statusCode, e.code, e.message)
+}
+
+// resolve finds the item addressed by the URL of a request relative to the drive.
+func (s *Server) resolve(itemURL string) (*itemTarget, *apiError) {
+	// The query of a search may contain colons and slashes, so it is split off before parsing the path.
+	var search string
+	if i := strings.Index(itemURL, "/search(q='"); i >= 0 {
+		itemURL, search = itemURL[:i], itemURL[i+1:]
+	}
+
+	parts := strings.SplitN(itemURL, ":", 3)
+	segments := strings.Split(parts[0], "/")
+
+	var base *item
+	switch {
+	case segments[0] == "root":
+		base, segments = s.items[s.rootId], segments[1:]
+	case segments[0] == "items" && len(segments) >= 2:
+		base, segments = s.items[segments[1]], segments[2:]
+	case segments[0] == "special" && len(segments) >= 2:
+		base, segments = s.specialFolder(segments[1]), segments[2:]
+	default:
+		return nil, errNotSupported
+	}
+
+	if base == nil || base.purged {
+		return nil, errItemNotFound
+	}
+
+	target := &itemTarget{item: base, action: strings.Join(segments, "/")}
+	if search != "" {
+		target.action = search
+	}
+
+	if len(parts) == 1 {
+		return target, nil
+	}
+
+	if len(segments) > 0 || search != "" || base.deleted {
+		return nil, errNotSupported
+	}
+
+	if len(parts) == 3 {
+		target.action = strings.Trim(parts[2], "/")
+	}
+
+	itemPath := splitPath(parts[1])
+	for i, segment := range itemPath {
+		child := s.child(target.item, segment)
+		if child == nil {
+			target.parent, target.item = target.item, nil
+			target.missingSegments = itemPath[i:]
+			break
+		}
+
+		target.item = child
+	}
+
+	return target, nil
+}
+
+// handleItem serves the requests to the items of the drive, whose URL relative to the drive is itemURL.
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request, itemURL string) {
+	target, err := s.resolve(itemURL)
+	if err != nil {
+		err.write(w)
+		return
+	}
+
+	// Only the new items can be uploaded to a path which does not exist yet.
+	if target.item == nil {
+		if target.action == "content" && r.Method == http.MethodPut {
+			s.uploadContent(w, r, target)
+		} else if target.action == "createUploadSession" && r.Method == http.MethodPost {
+			s.createUploadSession(w, r, target)
+		} else {
+			errItemNotFound.write(w)
+		}
+
+		return
+	}
+
+	it := target.item
+	if it.deleted && target.action != "restore" {
+		errItemNotFound.write(w)
+		return
+	}
+
+	action := target.action
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.snapshot(it))
+	case action == "" && r.Method == http.MethodPatch:
+		s.updateItem(w, r, it)
+	case action == "" && r.Method == http.MethodDelete:
+		s.deleteItem(w, it, false)
+	case action == "permanentDelete" && r.Method == http.MethodPost:
+		s.deleteItem(w, it, true)
+	case action == "restore" && r.Method == http.MethodPost:
+		s.restoreItem(w, r, it)
+	case action == "children" && r.Method == http.MethodGet:
+		s.listChildren(w, it)
+	case action == "children" && r.Method == http.MethodPost:
+		s.createFolder(w, r, it)
+	case action == "content" && r.Method == http.MethodGet:
+		s.redirectToContent(w, it)
+	case action == "content" && r.Method == http.MethodPut:
+		s.uploadContent(w, r, target)
+	case action == "createUploadSession" && r.Method == http.MethodPost:
+		s.createUploadSession(w, r, target)
+	case action == "copy" && r.Method == http.MethodPost:
+		s.copyItem(w, r, it)
+	case action == "delta" && r.Method == http.MethodGet:
+		s.delta(w, r, it)
+	case strings.HasPrefix(action, "search(q='") && r.Method == http.MethodGet:
+		s.search(w, it, action)
+	case action == "permissions" || strings.HasPrefix(action, "permissions/") || action == "createLink" || action == "invite":
+		s.handlePermissions(w, r, it, action)
+	default:
+		errNotSupported.write(w)
+	}
+}
+
+// decodeBody decodes the JSON body of the request into v.
+func decodeBody(r *http.Request, v interface{}) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errMalformedBody
+	}
+
+	return nil
+}
+
+// conflictBehavior returns the conflict behavior given in the query of the request, or the default one.
+func conflictBehavior(r *http.Request, defaultBehavior string) string {
+	if behavior := r.URL.Query().Get("@microsoft.graph.conflictBehavior"); behavior != "" {
+		return behavior
+	}
+
+	return defaultBehavior
+}
+
+// placeItem returns the name which an item named name should get in the parent folder according to the
+// conflict behavior, along with the existing item to be replaced, if any.
+func (s *Server) placeItem(parent *item, name string, behavior string) (string, *item, *apiError) {
+	existing := s.child(parent, name)
+	if existing == nil {
+		return name, nil, nil
+	}
+
+	switch behavior {
+	case conflictReplace:
+		return name, existing, nil
+	case conflictRename:
+		extension := path.Ext(name)
+		if existing.isFolder {
+			extension = ""
+		}
+
+		baseName := strings.TrimSuffix(name, extension)
+		for i := 1; ; i++ {
+			newName := baseName + " " + strconv.Itoa(i) + extension
+			if s.child(parent, newName) == nil {
+				return newName, nil, nil
+			}
+		}
+	default:
+		return "", nil, errNameConflict
+	}
+}
+
+// checkQuota returns an error if the drive does not have enough space for the content of the given size
+// replacing the content of the existing file, if any.
+func (s *Server) checkQuota(size int64, existing *item) *apiError {
+	remaining := s.drive().Quota.Remaining
+	if existing != nil && !existing.isFolder {
+		remaining += int64(len(existing.content))
+	}
+
+	if size > remaining {
+		return errQuotaExceeded
+	}
+
+	return nil
+}
+
+// writeFile creates the file named name in the parent folder with the content, or replaces the content of
+// the existing file according to the conflict behavior, and writes the file as the response.
+func (s *Server) writeFile(w http.ResponseWriter, parent *item, name string, behavior string, content []byte, mimeType string) {
+	name, existing, err := s.placeItem(parent, name, behavior)
+	if err != nil {
+		err.write(w)
+		return
+	}
+
+	if err := s.checkQuota(int64(len(content)), existing); err != nil {
+		err.write(w)
+		return
+	}
+
+	if existing != nil && !existing.isFolder {
+		s.setContent(existing, content, mimeType)
+		writeJSON(w, http.StatusOK, s.snapshot(existing))
+		return
+	}
+
+	if existing != nil {
+		s.removeItem(existing, true)
+	}
+
+	file := s.newItem(parent, name, false)
+	s.setContent(file, content, mimeType)
+
+	writeJSON(w, http.StatusCreated, s.snapshot(file))
+}
+
+// uploadContent serves the simple uploads, which create a new file or replace the content of an existing file.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_put_content?view=odsp-graph-online
+func (s *Server) uploadContent(w http.ResponseWriter, r *http.Request, target *itemTarget) {
+	content, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		errMalformedBody.write(w)
+		return
+	}
+
+	mimeType := r.Header.Get("Content-Type")
+
+	if target.item != nil {
+		if target.item.isFolder {
+			errNotAFile.write(w)
+			return
+		}
+
+		parent := s.items[target.item.parentId]
+		s.writeFile(w, parent, target.item.driveItem.Name, conflictBehavior(r, conflictReplace), content, mimeType)
+		return
+	}
+
+	if !target.parent.isFolder {
+		errNotAFolder.write(w)
+		return
+	}
+
+	segments := target.missingSegments
+	parent := s.mkdirAll(target.parent, segments[:len(segments)-1])
+
+	s.writeFile(w, parent, segments[len(segments)-1], conflictBehavior(r, conflictReplace), content, mimeType)
+}
+
+// redirectToContent redirects the request for the content of a file to its pre-authenticated download URL.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *Server) redirectToContent(w http.ResponseWriter, it *item) {
+	if it.isFolder {
+		errNotAFile.write(w)
+		return
+	}
+
+	w.Header().Set("Location", s.downloadURL(it))
+	w.WriteHeader(http.StatusFound)
+}
+
+// handleDownload serves the pre-authenticated download URLs of the files, including the range requests.
+func (s *Server) handleDownload(w http.ResponseWriter, r *http.Request, itemId string) {
+	it := s.items[itemId]
+	if !it.exists() || it.isFolder {
+		errItemNotFound.write(w)
+		return
+	}
+
+	if r.URL.Query().Get("generation") != strconv.Itoa(s.urlGeneration) {
+		writeError(w, http.StatusUnauthorized, "unauthenticated", "The download URL has expired.")
+		return
+	}
+
+	snapshot := s.snapshot(it)
+	w.Header().Set("Content-Type", it.driveItem.File.MIMEType)
+	w.Header().Set("ETag", snapshot.ETag)
+
+	http.ServeContent(w, r, it.driveItem.Name, it.driveItem.LastModifiedDateTime, strings.NewReader(string(it.content)))
+}
+
+// listChildren serves the children of a folder.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_children?view=odsp-graph-online
+func (s *Server) listChildren(w http.ResponseWriter, folder *item) {
+	if !folder.isFolder {
+		errNotAFolder.write(w)
+		return
+	}
+
+	response := &onedrive.OneDriveDriveItemsResponse{DriveItems: []*onedrive.DriveItem{}}
+	for _, child := range s.children(folder) {
+		response.DriveItems = append(response.DriveItems, s.snapshot(child))
+	}
+
+	response.Count = len(response.DriveItems)
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// createFolder creates a new folder in a folder. The default conflict behavior is to fail.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_post_children?view=odsp-graph-online
+func (s *Server) createFolder(w http.ResponseWriter, r *http.Request, parent *item) {
+	if !parent.isFolder {
+		errNotAFolder.write(w)
+		return
+	}
+
+	var body struct {
+		Name             string           `json:"name"`
+		Folder           *json.RawMessage `json:"folder"`
+		ConflictBehavior string           `json:"@microsoft.graph.conflictBehavior"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		err.write(w)
+		return
+	}
+
+	if body.Name == "" || body.Folder == nil {
+		errMalformedBody.write(w)
+		return
+	}
+
+	behavior := body.ConflictBehavior
+	if behavior == "" {
+		behavior = conflictBehavior(r, conflictFail)
+	}
+
+	name, existing, err := s.placeItem(parent, body.Name, behavior)
+	if err != nil {
+		err.write(w)
+		return
+	}
+
+	if existing != nil {
+		s.removeItem(existing, true)
+	}
+
+	folder := s.newItem(parent, name, true)
+
+	writeJSON(w, http.StatusCreated, s.snapshot(folder))
+}
+
+// updateItem renames, moves or changes the description of an item. The default conflict behavior is to fail.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_update?view=odsp-graph-online
+func (s *Server) updateItem(w http.ResponseWriter, r *http.Request, it *item) {
+	if it.parentId == "" {
+		errAccessDenied.write(w)
+		return
+	}
+
+	var body struct {
+		Name            *string                   `json:"name"`
+		Description     *string                   `json:"description"`
+		ParentReference *onedrive.ParentReference `json:"parentReference"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		err.write(w)
+		return
+	}
+
+	parent := s.items[it.parentId]
+	if body.ParentReference != nil && body.ParentReference.Id != "" {
+		parent = s.items[body.ParentReference.Id]
+		if !parent.exists() {
+			errItemNotFound.write(w)
+			return
+		}
+
+		if !parent.isFolder {
+			errNotAFolder.write(w)
+			return
+		}
+
+		if s.isAncestor(it, parent) {
+			errInvalidMove.write(w)
+			return
+		}
+	}
+
+	name := it.driveItem.Name
+	if body.Name != nil && *body.Name != "" {
+		name = *body.Name
+	}
+
+	if existing := s.child(parent, name); existing != nil && existing != it {
+		newName, replaced, err := s.placeItem(parent, name, conflictBehavior(r, conflictFail))
+		if err != nil {
+			err.write(w)
+			return
+		}
+
+		if replaced != nil {
+			s.removeItem(replaced, true)
+		}
+
+		name = newName
+	}
+
+	if oldParent := s.items[it.parentId]; oldParent != parent {
+		s.touch(oldParent)
+		s.touch(parent)
+	}
+
+	it.parentId = parent.driveItem.Id
+	it.driveItem.Name = name
+
+	if body.Description != nil {
+		it.driveItem.Description = *body.Description
+	}
+
+	s.touch(it)
+
+	writeJSON(w, http.StatusOK, s.snapshot(it))
+}
+
+// deleteItem moves an item, along with its descendants, to the recycle bin, or deletes it permanently.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_delete?view=odsp-graph-online
+func (s *Server) deleteItem(w http.ResponseWriter, it *item, permanent bool) {
+	if it.parentId == "" {
+		errAccessDenied.write(w)
+		return
+	}
+
+	s.removeItem(it, permanent)
+	s.touch(s.items[it.parentId])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeItem deletes an item and its descendants.
+func (s *Server) removeItem(it *item, permanent bool) {
+	for _, descendant := range s.items {
+		if !descendant.exists() || !s.isAncestor(it, descendant) {
+			continue
+		}
+
+		if permanent {
+			descendant.purged = true
+		} else {
+			descendant.deleted = true
+			descendant.deletedWith = it.driveItem.Id
+		}
+
+		s.touch(descendant)
+	}
+}
+
+// restoreItem restores a deleted item, along with the descendants deleted with it, from the recycle bin.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/driveitem-restore?view=graph-rest-1.0
+func (s *Server) restoreItem(w http.ResponseWriter, r *http.Request, it *item) {
+	if !it.deleted || it.deletedWith != it.driveItem.Id {
+		errNotRestorable.write(w)
+		return
+	}
+
+	var body onedrive.RestoreItemRequest
+	if r.ContentLength != 0 {
+		if err := decodeBody(r, &body); err != nil {
+			err.write(w)
+			return
+		}
+	}
+
+	parent := s.items[it.parentId]
+	if body.ParentFolder != nil && body.ParentFolder.Id != "" {
+		parent = s.items[body.ParentFolder.Id]
+	}
+
+	if !parent.exists() || !parent.isFolder {
+		errItemNotFound.write(w)
+		return
+	}
+
+	name := it.driveItem.Name
+	if body.Name != "" {
+		name = body.Name
+	}
+
+	if s.child(parent, name) != nil {
+		errNameConflict.write(w)
+		return
+	}
+
+	for _, descendant := range s.items {
+		if descendant.deleted && descendant.deletedWith == it.driveItem.Id {
+			descendant.deleted = false
+			descendant.deletedWith = ""
+			s.touch(descendant)
+		}
+	}
+
+	it.parentId = parent.driveItem.Id
+	it.driveItem.Name = name
+	s.touch(parent)
+
+	writeJSON(w, http.StatusOK, s.snapshot(it))
+}
+
+// search serves the items in a folder, including the folders below it, whose names contain the query of the
+// search function, e.g. "search(q='report')".
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_search?view=odsp-graph-online
+func (s *Server) search(w http.ResponseWriter, folder *item, searchFunction string) {
+	query := strings.TrimSuffix(strings.TrimPrefix(searchFunction, "search(q='"), "')")
+	query = strings.ToLower(strings.Replace(query, "''", "'", -1))
+
+	response := &onedrive.OneDriveDriveSearchResponse{DriveItems: []*onedrive.DriveItem{}}
+	for _, it := range s.sortedItems() {
+		if it.exists() && it != folder && s.isAncestor(folder, it) && strings.Contains(strings.ToLower(it.driveItem.Name), query) {
+			response.DriveItems = append(response.DriveItems, s.snapshot(it))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
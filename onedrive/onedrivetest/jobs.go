@@ -0,0 +1,167 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"net/http"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// copyJob is an async job copying an item. The copy is done when the job is polled after its remaining polls.
+type copyJob struct {
+	sourceId       string
+	parentId       string
+	name           string
+	behavior       string
+	totalPolls     int
+	remainingPolls int
+	result         *onedrive.OneDriveAsyncJobMonitorResponse // nil until the job has ended.
+}
+
+// copyItem starts a job copying an item, along with its descendants, and returns the monitor URL of the job
+// in the Location header. The default conflict behavior is to fail.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_copy?view=odsp-graph-online
+func (s *Server) copyItem(w http.ResponseWriter, r *http.Request, source *item) {
+	var body onedrive.CopyItemRequest
+	if err := decodeBody(r, &body); err != nil {
+		err.write(w)
+		return
+	}
+
+	if body.ParentFolder.DriveId != "" && body.ParentFolder.DriveId != s.DriveId {
+		writeError(w, http.StatusNotFound, "itemNotFound", "The drive could not be found.")
+		return
+	}
+
+	parent := s.items[source.parentId]
+	if body.ParentFolder.Id != "" {
+		parent = s.items[body.ParentFolder.Id]
+	}
+
+	if !parent.exists() {
+		errItemNotFound.write(w)
+		return
+	}
+
+	if !parent.isFolder {
+		errNotAFolder.write(w)
+		return
+	}
+
+	if s.isAncestor(source, parent) {
+		errInvalidMove.write(w)
+		return
+	}
+
+	name := body.Name
+	if name == "" {
+		name = source.driveItem.Name
+	}
+
+	jobId := s.newResourceId("job")
+	s.jobs[jobId] = &copyJob{
+		sourceId:       source.driveItem.Id,
+		parentId:       parent.driveItem.Id,
+		name:           name,
+		behavior:       conflictBehavior(r, conflictFail),
+		totalPolls:     s.copyPolls,
+		remainingPolls: s.copyPolls,
+	}
+
+	w.Header().Set("Location", s.server.URL+monitorPath+jobId)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleMonitor serves the monitor URL of a copy job.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/long-running-actions?view=odsp-graph-online
+func (s *Server) handleMonitor(w http.ResponseWriter, r *http.Request, jobId string) {
+	job, ok := s.jobs[jobId]
+	if !ok {
+		errItemNotFound.write(w)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if job.result == nil && job.remainingPolls > 0 {
+		job.remainingPolls--
+
+		writeJSON(w, http.StatusAccepted, &onedrive.OneDriveAsyncJobMonitorResponse{
+			Operation:           "ItemCopy",
+			Status:              "inProgress",
+			PercentageCompleted: float64(job.totalPolls-job.remainingPolls) * 100 / float64(job.totalPolls+1),
+		})
+		return
+	}
+
+	if job.result == nil {
+		job.result = s.runCopyJob(job)
+	}
+
+	writeJSON(w, http.StatusOK, job.result)
+}
+
+// runCopyJob copies the item of the job and returns the final status of the job.
+func (s *Server) runCopyJob(job *copyJob) *onedrive.OneDriveAsyncJobMonitorResponse {
+	result := &onedrive.OneDriveAsyncJobMonitorResponse{Operation: "ItemCopy", Status: "failed"}
+
+	source, parent := s.items[job.sourceId], s.items[job.parentId]
+	if !source.exists() || !parent.exists() {
+		result.ErrorCode = errItemNotFound.code
+		result.StatusDescription = errItemNotFound.message
+		return result
+	}
+
+	name, existing, err := s.placeItem(parent, job.name, job.behavior)
+	if err == nil {
+		err = s.checkQuota(s.size(source), existing)
+	}
+
+	if err != nil {
+		result.ErrorCode = err.code
+		result.StatusDescription = err.message
+		return result
+	}
+
+	if existing != nil {
+		s.removeItem(existing, true)
+	}
+
+	copied := s.copyTree(source, parent, name)
+
+	result.Status = "completed"
+	result.ResourceId = copied.driveItem.Id
+	result.PercentageCompleted = 100
+
+	return result
+}
+
+// copyTree copies the item, along with its descendants, into the parent folder with the given name.
+func (s *Server) copyTree(source *item, parent *item, name string) *item {
+	children := s.children(source)
+
+	copied := s.newItem(parent, name, source.isFolder)
+
+	driveItem := source.driveItem
+	driveItem.Id = copied.driveItem.Id
+	driveItem.Name = name
+	driveItem.CreatedDateTime = copied.driveItem.CreatedDateTime
+	driveItem.LastModifiedDateTime = copied.driveItem.LastModifiedDateTime
+
+	copied.driveItem = driveItem
+	copied.content = append([]byte(nil), source.content...)
+
+	for _, child := range children {
+		s.copyTree(child, copied, child.driveItem.Name)
+	}
+
+	return copied
+}
@@ -0,0 +1,169 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+var (
+	errPermissionNotFound = &apiError{http.StatusNotFound, "itemNotFound", "The permission could not be found."}
+	errInvalidLinkType    = &apiError{http.StatusBadRequest, "invalidRequest", "The type of the sharing link must be provided."}
+	errInvalidInvitation  = &apiError{http.StatusBadRequest, "invalidRequest", "The recipients and the roles of the invitation must be provided."}
+)
+
+// handlePermissions serves the requests to the permissions of an item, including the creation of sharing
+// links and the invitations.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/permission?view=odsp-graph-online
+func (s *Server) handlePermissions(w http.ResponseWriter, r *http.Request, it *item, action string) {
+	switch {
+	case action == "permissions" && r.Method == http.MethodGet:
+		response := &onedrive.ListPermissionsResponse{Value: []onedrive.Permission{}}
+		for _, permission := range it.permissions {
+			response.Value = append(response.Value, *permission)
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	case action == "createLink" && r.Method == http.MethodPost:
+		s.createLink(w, r, it)
+	case action == "invite" && r.Method == http.MethodPost:
+		s.invite(w, r, it)
+	case strings.HasPrefix(action, "permissions/"):
+		s.handlePermission(w, r, it, strings.TrimPrefix(action, "permissions/"))
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// handlePermission serves the requests to retrieve, update or delete a permission of an item.
+func (s *Server) handlePermission(w http.ResponseWriter, r *http.Request, it *item, permissionId string) {
+	index := -1
+	for i, permission := range it.permissions {
+		if permission.ID == permissionId {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		errPermissionNotFound.write(w)
+		return
+	}
+
+	permission := it.permissions[index]
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, permission)
+	case http.MethodPatch:
+		var body onedrive.UpdatePermissionRequest
+		if err := decodeBody(r, &body); err != nil {
+			err.write(w)
+			return
+		}
+
+		if len(body.Roles) > 0 {
+			permission.Roles = body.Roles
+		}
+
+		if body.ExpirationDateTime != "" {
+			permission.ExpirationDateTime = body.ExpirationDateTime
+		}
+
+		writeJSON(w, http.StatusOK, permission)
+	case http.MethodDelete:
+		it.permissions = append(it.permissions[:index:index], it.permissions[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// createLink creates a sharing link of an item. The existing link is returned if there is already a link
+// of the same type and scope.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createlink?view=odsp-graph-online
+func (s *Server) createLink(w http.ResponseWriter, r *http.Request, it *item) {
+	var body onedrive.CreateShareLinkRequest
+	if err := decodeBody(r, &body); err != nil {
+		err.write(w)
+		return
+	}
+
+	if body.Type == "" {
+		errInvalidLinkType.write(w)
+		return
+	}
+
+	role := "read"
+	if body.Type == "edit" {
+		role = "write"
+	}
+
+	if body.Scope == "" {
+		body.Scope = "anonymous"
+	}
+
+	for _, permission := range it.permissions {
+		if permission.Link.Type == body.Type && permission.Link.Scope == body.Scope {
+			writeJSON(w, http.StatusOK, permission)
+			return
+		}
+	}
+
+	permissionId := s.newResourceId("permission")
+	permission := &onedrive.Permission{
+		ID:    permissionId,
+		Roles: []string{role},
+		Link: onedrive.SharingLink{
+			Type:  body.Type,
+			Scope: body.Scope,
+			URL:   s.server.URL + "/share/" + permissionId,
+		},
+		ShareId:            "s!" + permissionId,
+		ExpirationDateTime: body.ExpirationDateTime,
+		HasPassword:        body.Password != "",
+	}
+	it.permissions = append(it.permissions, permission)
+
+	writeJSON(w, http.StatusCreated, permission)
+}
+
+// invite grants the roles to the recipients of an invitation, with a new permission for each recipient.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_invite?view=odsp-graph-online
+func (s *Server) invite(w http.ResponseWriter, r *http.Request, it *item) {
+	var body onedrive.InviteRequest
+	if err := decodeBody(r, &body); err != nil {
+		err.write(w)
+		return
+	}
+
+	if len(body.Recipients) == 0 || len(body.Roles) == 0 {
+		errInvalidInvitation.write(w)
+		return
+	}
+
+	response := &onedrive.InviteResponse{}
+	for _, recipient := range body.Recipients {
+		permission := &onedrive.Permission{
+			ID:    s.newResourceId("permission"),
+			Roles: append([]string(nil), body.Roles...),
+			GrantedTo: &onedrive.IdentitySet{
+				User: &onedrive.Identity{Id: recipient.ObjectId, DisplayName: recipient.Email, Email: recipient.Email},
+			},
+			Invitation:         &onedrive.SharingInvitation{Email: recipient.Email, SignInRequired: body.RequireSignIn},
+			ExpirationDateTime: body.ExpirationDateTime,
+		}
+
+		it.permissions = append(it.permissions, permission)
+		response.Value = append(response.Value, *permission)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
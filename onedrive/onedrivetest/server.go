@@ -0,0 +1,685 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+// Package onedrivetest provides an in-memory fake of the OneDrive API for testing code which uses
+// the onedrive package.
+//
+// The fake server keeps a single drive in memory, which is also the default drive of the user, and
+// serves the items, children, path addressing, uploads (including upload sessions), downloads, copy
+// jobs and their monitors, delta, permissions and sharing links of the drive, honoring the conflict
+// behavior of the requests. Errors and throttling can be injected to test the error handling.
+//
+// The Client of the server is pre-wired to it. For example:
+//
+//	server := onedrivetest.NewServer()
+//	defer server.Close()
+//
+//	server.AddFile("Documents/report.txt", []byte("Hello"))
+//
+//	item, err := server.Client.DriveItems.GetByPath(ctx, "", "Documents/report.txt")
+package onedrivetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+const (
+	// DefaultDriveId is the ID of the drive served by a new Server.
+	DefaultDriveId = "0123456789abcdef"
+
+	// DefaultQuota is the total space of the drive served by a new Server, in bytes.
+	DefaultQuota int64 = 1 << 40
+
+	apiPath      = "/v1.0/"
+	uploadPath   = "/upload/"
+	downloadPath = "/download/"
+
+	// monitorPath is the path of the monitor URLs of the copy jobs, which are allowed by the client of the
+	// server since the origin of the server is one of its MonitorHosts.
+	monitorPath = "/monitor/"
+
+	defaultDeltaPageSize = 200
+)
+
+// specialFolderPaths are the paths of the special folders, by their names in the OneDrive API.
+var specialFolderPaths = map[string]string{
+	"documents":  "Documents",
+	"photos":     "Pictures",
+	"cameraroll": "Pictures/Camera Roll",
+	"approot":    "Apps/onedrivetest",
+	"music":      "Music",
+	"recordings": "Recordings",
+}
+
+// Server is a fake OneDrive API server running on an httptest.Server.
+//
+// All the methods of Server are safe for concurrent use, so the content of the drive can be inspected
+// and changed while the code under test is talking to the server.
+type Server struct {
+	// URL is the base URL of the fake OneDrive API, with a trailing slash.
+	URL string
+
+	// DriveId is the ID of the drive served, which is also the default drive of the user.
+	DriveId string
+
+	// Client is a OneDrive client which is configured to talk to the server. Another client talking to the
+	// server, e.g. one created by the code under test, needs the origin of URL in its MonitorHosts to wait
+	// for the copy jobs.
+	Client *onedrive.Client
+
+	server *httptest.Server
+
+	mu             sync.Mutex
+	items          map[string]*item
+	rootId         string
+	specialFolders map[string]string
+	sequence       int64
+	lastId         int64
+	lastResourceId int64
+	lastRequestId  int64
+	quota          int64
+	deltaPageSize  int
+	copyPolls      int
+	urlGeneration  int
+	jobs           map[string]*copyJob
+	sessions       map[string]*uploadSession
+	faults         []*Fault
+}
+
+// item is an item stored in the drive.
+type item struct {
+	driveItem   onedrive.DriveItem
+	parentId    string
+	isFolder    bool
+	content     []byte
+	permissions []*onedrive.Permission
+
+	version  int   // Incremented whenever the item is changed.
+	sequence int64 // The sequence number of the last change of the item, for delta.
+
+	deleted     bool   // The item is in the recycle bin.
+	purged      bool   // The item is permanently deleted. It is only kept to be reported by delta.
+	deletedWith string // The ID of the item whose deletion has deleted this item.
+}
+
+// NewServer starts and returns a new fake OneDrive API server with an empty drive.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		DriveId:        DefaultDriveId,
+		items:          make(map[string]*item),
+		specialFolders: make(map[string]string),
+		quota:          DefaultQuota,
+		deltaPageSize:  defaultDeltaPageSize,
+		jobs:           make(map[string]*copyJob),
+		sessions:       make(map[string]*uploadSession),
+	}
+
+	root := s.newItem(nil, "root", true)
+	s.rootId = root.driveItem.Id
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL + apiPath
+
+	s.Client = onedrive.NewClient(s.server.Client())
+	s.Client.BaseURL, _ = url.Parse(s.URL)
+	s.Client.MonitorHosts = append(s.Client.MonitorHosts, s.server.URL)
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetQuota sets the total space of the drive, in bytes. Uploads exceeding the remaining space are rejected.
+func (s *Server) SetQuota(total int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.quota = total
+}
+
+// SetDeltaPageSize sets the maximum number of items in each page of the delta responses. Defaults to 200.
+func (s *Server) SetDeltaPageSize(pageSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pageSize <= 0 {
+		pageSize = defaultDeltaPageSize
+	}
+
+	s.deltaPageSize = pageSize
+}
+
+// SetCopyPolls sets the number of times the monitor of a copy job reports the job in progress before
+// the copy is done. Defaults to 0, i.e. the copy is done when its monitor is polled for the first time.
+func (s *Server) SetCopyPolls(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.copyPolls = polls
+}
+
+// ExpireDownloadURLs makes all the download URLs returned so far invalid, just like the pre-authenticated
+// download URLs of OneDrive expire after a while.
+func (s *Server) ExpireDownloadURLs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.urlGeneration++
+}
+
+// AddFolder creates the folder at the given path, which is relative to the root folder of the drive,
+// as well as its missing parent folders, and returns it. The existing folder is returned if there is any.
+func (s *Server) AddFolder(folderPath string) *onedrive.DriveItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snapshot(s.mkdirAll(s.items[s.rootId], splitPath(folderPath)))
+}
+
+// AddFile creates the file at the given path, which is relative to the root folder of the drive, with the
+// given content and returns it. The missing parent folders are created, and the existing file is replaced.
+func (s *Server) AddFile(filePath string, content []byte) *onedrive.DriveItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := splitPath(filePath)
+	if len(segments) == 0 {
+		return nil
+	}
+
+	parent := s.mkdirAll(s.items[s.rootId], segments[:len(segments)-1])
+	name := segments[len(segments)-1]
+
+	if existing := s.child(parent, name); existing != nil && !existing.isFolder {
+		s.setContent(existing, content, "")
+		return s.snapshot(existing)
+	}
+
+	file := s.newItem(parent, name, false)
+	s.setContent(file, content, "")
+
+	return s.snapshot(file)
+}
+
+// UpdateItem changes the metadata of an item, e.g. its audio or photo facets, with the given function and
+// returns the updated item. The properties managed by the server, such as the ID, the name, the size
+// and the parent reference, are not changed. It returns nil if the item does not exist.
+func (s *Server) UpdateItem(itemId string, update func(driveItem *onedrive.DriveItem)) *onedrive.DriveItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.items[itemId]
+	if !it.exists() {
+		return nil
+	}
+
+	id, name, file := it.driveItem.Id, it.driveItem.Name, it.driveItem.File
+	update(&it.driveItem)
+	it.driveItem.Id, it.driveItem.Name = id, name
+
+	if it.isFolder || it.driveItem.File == nil {
+		it.driveItem.File = file
+	}
+
+	s.touch(it)
+
+	return s.snapshot(it)
+}
+
+// Item returns the item with the given ID, or nil if it does not exist.
+func (s *Server) Item(itemId string) *onedrive.DriveItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.items[itemId]
+	if !it.exists() {
+		return nil
+	}
+
+	return s.snapshot(it)
+}
+
+// ItemByPath returns the item at the given path, which is relative to the root folder of the drive,
+// or nil if it does not exist. An empty path refers to the root folder.
+func (s *Server) ItemByPath(itemPath string) *onedrive.DriveItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.items[s.rootId]
+	for _, segment := range splitPath(itemPath) {
+		if it = s.child(it, segment); it == nil {
+			return nil
+		}
+	}
+
+	return s.snapshot(it)
+}
+
+// Content returns the content of the file with the given ID, or nil if it is not an existing file.
+func (s *Server) Content(itemId string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.items[itemId]
+	if !it.exists() || it.isFolder {
+		return nil
+	}
+
+	return append([]byte(nil), it.content...)
+}
+
+// Permissions returns the permissions of the item with the given ID.
+func (s *Server) Permissions(itemId string) []onedrive.Permission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.items[itemId]
+	if !it.exists() {
+		return nil
+	}
+
+	var permissions []onedrive.Permission
+	for _, permission := range it.permissions {
+		permissions = append(permissions, *permission)
+	}
+
+	return permissions
+}
+
+// handle serves all the requests to the server.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Like the OneDrive API, every response carries the ID of its request, and echoes the ID of the client.
+	s.lastRequestId++
+	w.Header().Set("request-id", "request"+strconv.FormatInt(s.lastRequestId, 10))
+	if clientRequestId := r.Header.Get("client-request-id"); clientRequestId != "" {
+		w.Header().Set("client-request-id", clientRequestId)
+	}
+
+	if s.injectFault(w, r) {
+		return
+	}
+
+	switch requestPath := r.URL.Path; {
+	case strings.HasPrefix(requestPath, apiPath):
+		s.handleAPI(w, r, strings.TrimPrefix(requestPath, apiPath))
+	case strings.HasPrefix(requestPath, uploadPath):
+		s.handleUploadSession(w, r, strings.TrimPrefix(requestPath, uploadPath))
+	case strings.HasPrefix(requestPath, downloadPath):
+		s.handleDownload(w, r, strings.TrimPrefix(requestPath, downloadPath))
+	case strings.HasPrefix(requestPath, monitorPath):
+		s.handleMonitor(w, r, strings.TrimPrefix(requestPath, monitorPath))
+	default:
+		writeError(w, http.StatusNotFound, "itemNotFound", "The resource could not be found.")
+	}
+}
+
+// handleAPI serves the requests to the OneDrive API, whose path relative to the API root is apiURL.
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request, apiURL string) {
+	if apiURL == "me" {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.user())
+		return
+	}
+
+	if apiURL == "me/drives" || isUserURL(apiURL, "drives") {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, &onedrive.OneDriveDrivesResponse{Drives: []*onedrive.Drive{s.drive()}})
+		return
+	}
+
+	var driveURL string
+	switch segments := strings.SplitN(apiURL, "/", 4); {
+	case len(segments) >= 2 && segments[0] == "me" && segments[1] == "drive":
+		driveURL = "me/drive"
+	case len(segments) >= 3 && segments[0] == "users" && segments[2] == "drive":
+		driveURL = "users/" + segments[1] + "/drive"
+	case len(segments) >= 2 && segments[0] == "drives":
+		if segments[1] != s.DriveId {
+			writeError(w, http.StatusNotFound, "itemNotFound", "The drive could not be found.")
+			return
+		}
+
+		driveURL = "drives/" + segments[1]
+	default:
+		writeError(w, http.StatusBadRequest, "invalidRequest", "The request is not supported by the fake OneDrive API.")
+		return
+	}
+
+	if apiURL == driveURL {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.drive())
+		return
+	}
+
+	if !strings.HasPrefix(apiURL, driveURL+"/") {
+		writeError(w, http.StatusBadRequest, "invalidRequest", "The request is not supported by the fake OneDrive API.")
+		return
+	}
+
+	s.handleItem(w, r, strings.TrimPrefix(apiURL, driveURL+"/"))
+}
+
+// isUserURL reports whether apiURL is "users/{user-id}/" followed by the given action.
+func isUserURL(apiURL string, action string) bool {
+	segments := strings.Split(apiURL, "/")
+
+	return len(segments) == 3 && segments[0] == "users" && segments[2] == action
+}
+
+// user returns the user of the drive.
+func (s *Server) user() *onedrive.User {
+	return &onedrive.User{
+		Id:                "fake-user",
+		DisplayName:       "Test User",
+		Email:             "test.user@example.com",
+		UserPrincipalName: "test.user@example.com",
+	}
+}
+
+// drive returns the drive with its current quota.
+func (s *Server) drive() *onedrive.Drive {
+	var used, deleted int64
+	for _, it := range s.items {
+		switch {
+		case it.isFolder || it.purged:
+		case it.deleted:
+			deleted += int64(len(it.content))
+		default:
+			used += int64(len(it.content))
+		}
+	}
+
+	remaining := s.quota - used - deleted
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	state := onedrive.QuotaStateNormal
+	switch {
+	case remaining == 0:
+		state = onedrive.QuotaStateExceeded
+	case remaining < s.quota/100:
+		state = onedrive.QuotaStateCritical
+	case remaining < s.quota/10:
+		state = onedrive.QuotaStateNearing
+	}
+
+	root := s.items[s.rootId]
+
+	return &onedrive.Drive{
+		Id:                   s.DriveId,
+		Name:                 "OneDrive",
+		WebURL:               s.server.URL + "/web",
+		DriveType:            "personal",
+		CreatedDateTime:      root.driveItem.CreatedDateTime,
+		LastModifiedDateTime: root.driveItem.LastModifiedDateTime,
+		Owner:                &onedrive.Owner{User: *s.user()},
+		Quota: &onedrive.DriveQuota{
+			Used:      used,
+			Deleted:   deleted,
+			Remaining: remaining,
+			Total:     s.quota,
+			State:     state,
+		},
+	}
+}
+
+// newItem creates a new item named name in the parent folder, which is changed as well.
+// The root folder is created if parent is nil.
+func (s *Server) newItem(parent *item, name string, isFolder bool) *item {
+	s.lastId++
+	now := time.Now().UTC()
+
+	it := &item{
+		driveItem: onedrive.DriveItem{
+			Id:                   strings.ToUpper(s.DriveId) + "!" + strconv.FormatInt(s.lastId, 10),
+			Name:                 name,
+			CreatedDateTime:      now,
+			LastModifiedDateTime: now,
+		},
+		isFolder: isFolder,
+	}
+
+	s.items[it.driveItem.Id] = it
+	s.touch(it)
+
+	if parent != nil {
+		it.parentId = parent.driveItem.Id
+		s.touch(parent)
+	}
+
+	return it
+}
+
+// touch records a change of the item.
+func (s *Server) touch(it *item) {
+	s.sequence++
+
+	it.version++
+	it.sequence = s.sequence
+	it.driveItem.LastModifiedDateTime = time.Now().UTC()
+}
+
+// setContent replaces the content of the file. If mimeType is empty, it is detected from the name and the content.
+func (s *Server) setContent(file *item, content []byte, mimeType string) {
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(path.Ext(file.driveItem.Name))
+	}
+
+	if mimeType == "" {
+		mimeType = http.DetectContentType(content)
+	}
+
+	file.content = append([]byte(nil), content...)
+	file.driveItem.File = &onedrive.DriveItemFile{MIMEType: mimeType}
+	s.touch(file)
+}
+
+// exists reports whether the item exists, i.e. it is neither deleted nor permanently deleted.
+func (it *item) exists() bool {
+	return it != nil && !it.deleted && !it.purged
+}
+
+// children returns the existing children of the folder, sorted by their names.
+func (s *Server) children(folder *item) []*item {
+	var children []*item
+	for _, it := range s.items {
+		if it.parentId == folder.driveItem.Id && it.exists() {
+			children = append(children, it)
+		}
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return strings.ToLower(children[i].driveItem.Name) < strings.ToLower(children[j].driveItem.Name)
+	})
+
+	return children
+}
+
+// child returns the existing child of the folder with the given name, which is case insensitive, if any.
+func (s *Server) child(folder *item, name string) *item {
+	if folder == nil || !folder.isFolder {
+		return nil
+	}
+
+	for _, it := range s.items {
+		if it.parentId == folder.driveItem.Id && it.exists() && strings.EqualFold(it.driveItem.Name, name) {
+			return it
+		}
+	}
+
+	return nil
+}
+
+// mkdirAll returns the folder at the given path relative to parent, creating the missing folders.
+func (s *Server) mkdirAll(parent *item, segments []string) *item {
+	for _, segment := range segments {
+		folder := s.child(parent, segment)
+		if folder == nil {
+			folder = s.newItem(parent, segment, true)
+		}
+
+		parent = folder
+	}
+
+	return parent
+}
+
+// specialFolder returns the special folder with the given name, creating it if it does not exist yet.
+func (s *Server) specialFolder(name string) *item {
+	name = strings.ToLower(name)
+
+	folderPath, ok := specialFolderPaths[name]
+	if !ok {
+		return nil
+	}
+
+	if it := s.items[s.specialFolders[name]]; it.exists() {
+		return it
+	}
+
+	folder := s.mkdirAll(s.items[s.rootId], splitPath(folderPath))
+	s.specialFolders[name] = folder.driveItem.Id
+
+	return folder
+}
+
+// isAncestor reports whether ancestor is the item itself or one of its ancestors, including the deleted ones.
+func (s *Server) isAncestor(ancestor *item, it *item) bool {
+	for ; it != nil; it = s.items[it.parentId] {
+		if it == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// itemPath returns the path of the item relative to the root folder, e.g. "/Documents/report.txt".
+func (s *Server) itemPath(it *item) string {
+	var segments []string
+	for ; it != nil && it.parentId != ""; it = s.items[it.parentId] {
+		segments = append([]string{it.driveItem.Name}, segments...)
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// size returns the size of the item, which is the total size of the files in it for a folder.
+func (s *Server) size(it *item) int64 {
+	if !it.isFolder {
+		return int64(len(it.content))
+	}
+
+	var size int64
+	for _, child := range s.children(it) {
+		size += s.size(child)
+	}
+
+	return size
+}
+
+// snapshot returns a copy of the item as returned by the OneDrive API.
+func (s *Server) snapshot(it *item) *onedrive.DriveItem {
+	driveItem := it.driveItem
+	id := driveItem.Id
+
+	driveItem.ETag = fmt.Sprintf(`"{%s},%d"`, id, it.version)
+	driveItem.CTag = fmt.Sprintf(`"c:{%s},%d"`, id, it.version)
+	driveItem.Size = s.size(it)
+	driveItem.WebURL = s.server.URL + "/web" + s.itemPath(it)
+
+	if it.isFolder {
+		driveItem.File = nil
+		driveItem.Folder = &onedrive.DriveItemFolder{ChildCount: int32(len(s.children(it)))}
+	} else {
+		driveItem.Folder = nil
+		driveItem.DownloadURL = s.downloadURL(it)
+	}
+
+	if parent := s.items[it.parentId]; parent != nil {
+		parentPath := "/drive/root:"
+		if parent.parentId != "" {
+			parentPath += s.itemPath(parent)
+		}
+
+		driveItem.ParentReference = &onedrive.ParentReference{Id: parent.driveItem.Id, Path: parentPath, DriveId: s.DriveId}
+	}
+
+	if it.deleted || it.purged {
+		driveItem.Deleted = &onedrive.DeletedFacet{State: "deleted"}
+		driveItem.DownloadURL = ""
+	}
+
+	return &driveItem
+}
+
+// downloadURL returns the pre-authenticated download URL of the file.
+func (s *Server) downloadURL(file *item) string {
+	query := url.Values{
+		"version":    {strconv.Itoa(file.version)},
+		"generation": {strconv.Itoa(s.urlGeneration)},
+	}
+
+	return s.server.URL + downloadPath + url.PathEscape(file.driveItem.Id) + "?" + query.Encode()
+}
+
+// splitPath splits a path relative to a folder into its segments.
+func splitPath(itemPath string) []string {
+	itemPath = strings.Trim(itemPath, "/")
+	if itemPath == "" {
+		return nil
+	}
+
+	return strings.Split(itemPath, "/")
+}
+
+// writeJSON writes the value as the JSON body of the response with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response of the OneDrive API.
+func writeError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, &onedrive.ErrorResponse{Error: &onedrive.Error{Code: code, Message: message}})
+}
+
+// writeMethodNotAllowed writes the error response for a request whose method is not supported.
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "invalidRequest", "The HTTP method is not supported by the resource.")
+}
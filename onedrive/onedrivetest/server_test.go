@@ -0,0 +1,377 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goh-chunlin/go-onedrive/onedrive"
+)
+
+// writeTempFile writes the content to a new file with the given name in a temporary folder, and returns
+// the path to the file along with a function removing the folder.
+func writeTempFile(t *testing.T, name string, content []byte) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "onedrivetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filePath := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filePath, content, 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	return filePath, func() { os.RemoveAll(dir) }
+}
+
+func TestServer_Items(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client
+
+	report := server.AddFile("Documents/report.txt", []byte("Hello"))
+	documents := server.ItemByPath("Documents")
+
+	gotItem, err := client.DriveItems.GetByPath(ctx, "", "documents/REPORT.txt")
+	if err != nil {
+		t.Fatalf("DriveItems.GetByPath returned error: %v", err)
+	}
+
+	if gotItem.Id != report.Id || gotItem.Size != 5 || gotItem.ParentReference.Id != documents.Id {
+		t.Errorf("DriveItems.GetByPath returned %+v, want %+v", gotItem, report)
+	}
+
+	// The client creates folders with the conflict behavior "rename".
+	for _, want := range []string{"Archive", "Archive 1"} {
+		folder, err := client.DriveItems.CreateNewFolder(ctx, "", documents.Id, "Archive")
+		if err != nil {
+			t.Fatalf("DriveItems.CreateNewFolder returned error: %v", err)
+		}
+
+		if folder.Name != want || folder.Folder == nil {
+			t.Errorf("DriveItems.CreateNewFolder returned %+v, want folder %q", folder, want)
+		}
+	}
+
+	archive := server.ItemByPath("Documents/Archive")
+
+	if _, err := client.DriveItems.Move(ctx, "", report.Id, archive.Id); err != nil {
+		t.Fatalf("DriveItems.Move returned error: %v", err)
+	}
+
+	if _, err := client.DriveItems.Rename(ctx, "", report.Id, "final.txt"); err != nil {
+		t.Fatalf("DriveItems.Rename returned error: %v", err)
+	}
+
+	children, err := client.DriveItems.ListByPath(ctx, "", "Documents/Archive")
+	if err != nil {
+		t.Fatalf("DriveItems.ListByPath returned error: %v", err)
+	}
+
+	if len(children.DriveItems) != 1 || children.DriveItems[0].Name != "final.txt" {
+		t.Errorf("DriveItems.ListByPath returned %+v, want final.txt", children.DriveItems)
+	}
+
+	// Renaming an item to the name of another item fails by default.
+	server.AddFile("Documents/Archive/other.txt", nil)
+	if _, err := client.DriveItems.Rename(ctx, "", report.Id, "Other.txt"); err == nil || !strings.Contains(err.Error(), "nameAlreadyExists") {
+		t.Errorf("DriveItems.Rename returned error %v, want nameAlreadyExists", err)
+	}
+
+	if err := client.DriveItems.Delete(ctx, "", archive.Id); err != nil {
+		t.Fatalf("DriveItems.Delete returned error: %v", err)
+	}
+
+	if server.Item(report.Id) != nil {
+		t.Errorf("Server.Item returned the item in the deleted folder")
+	}
+
+	if _, err := client.DriveItems.GetInDrive(ctx, server.DriveId, report.Id); err == nil || !strings.Contains(err.Error(), "itemNotFound") {
+		t.Errorf("DriveItems.GetInDrive returned error %v, want itemNotFound", err)
+	}
+
+	if _, err := client.DriveItems.Restore(ctx, "", archive.Id, "", ""); err != nil {
+		t.Fatalf("DriveItems.Restore returned error: %v", err)
+	}
+
+	if got := server.ItemByPath("Documents/Archive/final.txt"); got == nil || string(server.Content(got.Id)) != "Hello" {
+		t.Errorf("Server.ItemByPath returned %+v after restoring the folder, want final.txt", got)
+	}
+}
+
+func TestServer_UploadAndDownload(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client
+
+	smallFilePath, removeSmallFile := writeTempFile(t, "notes.txt", []byte("Some notes"))
+	defer removeSmallFile()
+
+	uploaded, err := client.DriveItems.UploadNewFileByPath(ctx, "", "Notes/2020", smallFilePath)
+	if err != nil {
+		t.Fatalf("DriveItems.UploadNewFileByPath returned error: %v", err)
+	}
+
+	if got := server.ItemByPath("Notes/2020/notes.txt"); got == nil || got.Id != uploaded.Id {
+		t.Errorf("Server.ItemByPath returned %+v, want %+v", got, uploaded)
+	}
+
+	// The client uploads new files with the conflict behavior "rename".
+	renamed, err := client.DriveItems.UploadNewFile(ctx, "", server.ItemByPath("Notes/2020").Id, smallFilePath)
+	if err != nil {
+		t.Fatalf("DriveItems.UploadNewFile returned error: %v", err)
+	}
+
+	if renamed.Name != "notes 1.txt" {
+		t.Errorf("DriveItems.UploadNewFile returned %q, want %q", renamed.Name, "notes 1.txt")
+	}
+
+	content, err := client.DriveItems.DownloadItem(ctx, uploaded)
+	if err != nil {
+		t.Fatalf("DriveItems.DownloadItem returned error: %v", err)
+	}
+
+	if string(content) != "Some notes" {
+		t.Errorf("DriveItems.DownloadItem returned %q, want %q", content, "Some notes")
+	}
+
+	largeContent := bytes.Repeat([]byte("0123456789"), 70000)
+	largeFilePath, removeLargeFile := writeTempFile(t, "video.bin", largeContent)
+	defer removeLargeFile()
+
+	largeFile, err := client.DriveItems.UploadNewFileLarge(ctx, "", server.ItemByPath("").Id, largeFilePath, 320*1024)
+	if err != nil {
+		t.Fatalf("DriveItems.UploadNewFileLarge returned error: %v", err)
+	}
+
+	if !bytes.Equal(server.Content(largeFile.Id), largeContent) {
+		t.Errorf("DriveItems.UploadNewFileLarge uploaded %d bytes, want %d bytes", len(server.Content(largeFile.Id)), len(largeContent))
+	}
+
+	server.SetQuota(int64(len(largeContent)) + 100)
+	client.CheckQuotaBeforeUpload = true
+
+	_, err = client.DriveItems.UploadNewFileLarge(ctx, "", server.ItemByPath("").Id, largeFilePath, 320*1024)
+	if _, ok := err.(*onedrive.InsufficientQuotaError); !ok {
+		t.Errorf("DriveItems.UploadNewFileLarge returned error %v, want *onedrive.InsufficientQuotaError", err)
+	}
+}
+
+func TestServer_Copy(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client
+
+	source := server.AddFile("Photos/2020/beach.jpg", []byte("beach"))
+	server.AddFile("Photos/2020/sunset.jpg", []byte("sunset"))
+	backup := server.AddFolder("Backup")
+
+	server.SetCopyPolls(2)
+
+	progress := make(chan *onedrive.OneDriveAsyncJobMonitorResponse, 10)
+	options := &onedrive.WaitOptions{InitialInterval: time.Millisecond, Progress: progress}
+
+	copied, err := client.DriveItems.CopyAndWait(ctx, "", source.ParentReference.Id, "", backup.Id, "2020", options)
+	if err != nil {
+		t.Fatalf("DriveItems.CopyAndWait returned error: %v", err)
+	}
+
+	if copied.Name != "2020" || copied.Folder == nil || copied.Folder.ChildCount != 2 {
+		t.Errorf("DriveItems.CopyAndWait returned %+v, want folder 2020 with 2 children", copied)
+	}
+
+	if got := len(progress); got != 3 {
+		t.Errorf("DriveItems.CopyAndWait reported %d statuses, want 3", got)
+	}
+
+	if got := server.ItemByPath("Backup/2020/sunset.jpg"); got == nil || string(server.Content(got.Id)) != "sunset" {
+		t.Errorf("Server.ItemByPath returned %+v, want the copy of sunset.jpg", got)
+	}
+
+	// The copy fails when an item with the same name already exists in the destination folder.
+	_, err = client.DriveItems.CopyAndWait(ctx, "", source.Id, "", backup.Id, "2020", &onedrive.WaitOptions{InitialInterval: time.Millisecond})
+	if jobError, ok := err.(*onedrive.AsyncJobError); !ok || jobError.ErrorCode != "nameAlreadyExists" {
+		t.Errorf("DriveItems.CopyAndWait returned error %v, want *onedrive.AsyncJobError with nameAlreadyExists", err)
+	}
+}
+
+func TestServer_Delta(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client
+
+	server.AddFile("Music/a.mp3", []byte("a"))
+	b := server.AddFile("Music/b.mp3", []byte("b"))
+	server.AddFile("Other/c.txt", []byte("c"))
+	music := server.ItemByPath("Music")
+
+	server.SetDeltaPageSize(1)
+
+	changes, err := client.DriveItems.Delta(ctx, "", music.Id, "")
+	if err != nil {
+		t.Fatalf("DriveItems.Delta returned error: %v", err)
+	}
+
+	if len(changes.DriveItems) != 3 || changes.DeltaLink == "" {
+		t.Fatalf("DriveItems.Delta returned %d items and delta link %q, want 3 items and a delta link", len(changes.DriveItems), changes.DeltaLink)
+	}
+
+	client.DriveItems.Delete(ctx, "", b.Id)
+	server.AddFile("Music/d.mp3", []byte("d"))
+	server.AddFile("Other/e.txt", []byte("e"))
+
+	changes, err = client.DriveItems.Delta(ctx, "", "", changes.DeltaLink)
+	if err != nil {
+		t.Fatalf("DriveItems.Delta returned error: %v", err)
+	}
+
+	deleted := map[string]bool{}
+	for _, driveItem := range changes.DriveItems {
+		deleted[driveItem.Name] = driveItem.Deleted != nil
+	}
+
+	want := map[string]bool{"Music": false, "b.mp3": true, "d.mp3": false}
+	if len(deleted) != len(want) {
+		t.Errorf("DriveItems.Delta returned %v, want %v", deleted, want)
+	}
+
+	for name, wantDeleted := range want {
+		if gotDeleted, ok := deleted[name]; !ok || gotDeleted != wantDeleted {
+			t.Errorf("DriveItems.Delta returned %v, want %v", deleted, want)
+			break
+		}
+	}
+}
+
+func TestServer_Permissions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client
+
+	report := server.AddFile("report.docx", []byte("report"))
+
+	link, err := client.DrivePermissions.CreateShareLink(ctx, report.Id, onedrive.View, onedrive.Anonymous)
+	if err != nil {
+		t.Fatalf("DrivePermissions.CreateShareLink returned error: %v", err)
+	}
+
+	sameLink, err := client.DrivePermissions.CreateShareLink(ctx, report.Id, onedrive.View, onedrive.Anonymous)
+	if err != nil {
+		t.Fatalf("DrivePermissions.CreateShareLink returned error: %v", err)
+	}
+
+	if link.ID == "" || sameLink.ID != link.ID || link.Link.Type != "view" {
+		t.Errorf("DrivePermissions.CreateShareLink returned %+v and %+v, want the same view link", link, sameLink)
+	}
+
+	invitation := &onedrive.Invitation{
+		Recipients: []onedrive.DriveRecipient{{Email: "ryan@contoso.com"}},
+		Roles:      []onedrive.PermissionRole{onedrive.Write},
+	}
+	if _, err := client.DrivePermissions.Invite(ctx, "", report.Id, invitation); err != nil {
+		t.Fatalf("DrivePermissions.Invite returned error: %v", err)
+	}
+
+	permissions, err := client.DrivePermissions.List(ctx, report.Id)
+	if err != nil {
+		t.Fatalf("DrivePermissions.List returned error: %v", err)
+	}
+
+	if len(permissions) != 2 || permissions[1].GrantedTo.User.Email != "ryan@contoso.com" || permissions[1].Roles[0] != "write" {
+		t.Errorf("DrivePermissions.List returned %+v, want the link and the invitation", permissions)
+	}
+
+	if err := client.DrivePermissions.Delete(ctx, "", report.Id, link.ID); err != nil {
+		t.Fatalf("DrivePermissions.Delete returned error: %v", err)
+	}
+
+	if got := server.Permissions(report.Id); len(got) != 1 {
+		t.Errorf("Server.Permissions returned %+v, want 1 permission", got)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client
+
+	server.AddFile("Documents/report.txt", []byte("report"))
+
+	server.Throttle(http.MethodGet, "/children", 10*time.Second, 1)
+
+	if _, err := client.DriveItems.ListByPath(ctx, "", "Documents"); err == nil || !strings.Contains(err.Error(), "activityLimitReached") {
+		t.Errorf("DriveItems.ListByPath returned error %v, want activityLimitReached", err)
+	}
+
+	if _, err := client.DriveItems.ListByPath(ctx, "", "Documents"); err != nil {
+		t.Errorf("DriveItems.ListByPath returned error %v after the throttling, want nil", err)
+	}
+
+	server.InjectFault(Fault{Path: "report.txt", StatusCode: http.StatusServiceUnavailable, Code: "serviceNotAvailable", Times: -1})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.DriveItems.GetByPath(ctx, "", "Documents/report.txt"); err == nil || !strings.Contains(err.Error(), "serviceNotAvailable") {
+			t.Errorf("DriveItems.GetByPath returned error %v, want serviceNotAvailable", err)
+		}
+	}
+
+	server.ClearFaults()
+
+	if _, err := client.DriveItems.GetByPath(ctx, "", "Documents/report.txt"); err != nil {
+		t.Errorf("DriveItems.GetByPath returned error %v after clearing the faults, want nil", err)
+	}
+}
+
+func TestServer_MediaHandler(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	song := server.AddFile("Music/song.mp3", []byte("0123456789"))
+	server.UpdateItem(song.Id, func(driveItem *onedrive.DriveItem) {
+		driveItem.File.MIMEType = "audio/mpeg"
+		driveItem.Audio = &onedrive.OneDriveAudio{Title: "Song"}
+	})
+
+	handler := onedrive.NewMediaHandler(server.Client, "")
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/Music/song.mp3", nil)
+		req.Header.Set("Range", "bytes=2-5")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPartialContent || rec.Body.String() != "2345" || rec.Header().Get("Content-Type") != "audio/mpeg" {
+			t.Errorf("MediaHandler returned status %d, body %q and Content-Type %q, want 206, %q and audio/mpeg",
+				rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"), "2345")
+		}
+
+		// The handler refreshes the download URL transparently when it has expired.
+		server.ExpireDownloadURLs()
+	}
+}
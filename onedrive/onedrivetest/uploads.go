@@ -0,0 +1,180 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrivetest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// uploadSessionLifetime is how long an upload session is valid after it is created or after its last fragment.
+const uploadSessionLifetime = 15 * time.Minute
+
+// uploadSession is an upload session of a large file.
+type uploadSession struct {
+	parentId           string
+	name               string
+	behavior           string
+	content            []byte
+	totalSize          int64 // Zero until the first fragment is uploaded.
+	expirationDateTime time.Time
+}
+
+// uploadSessionStatus represents the JSON object returned by the OneDrive API for an upload session
+// which has not been completed yet.
+type uploadSessionStatus struct {
+	UploadURL          string   `json:"uploadUrl,omitempty"`
+	ExpirationDateTime string   `json:"expirationDateTime"`
+	NextExpectedRanges []string `json:"nextExpectedRanges"`
+}
+
+// newResourceId returns a new ID for a resource which is not an item, e.g. an upload session or a permission.
+func (s *Server) newResourceId(prefix string) string {
+	s.lastResourceId++
+
+	return prefix + strconv.FormatInt(s.lastResourceId, 10)
+}
+
+// createUploadSession creates an upload session for a file. The default conflict behavior is to replace.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online
+func (s *Server) createUploadSession(w http.ResponseWriter, r *http.Request, target *itemTarget) {
+	var body struct {
+		Item struct {
+			ConflictBehavior string `json:"@microsoft.graph.conflictBehavior"`
+		} `json:"item"`
+	}
+	if r.ContentLength != 0 {
+		if err := decodeBody(r, &body); err != nil {
+			err.write(w)
+			return
+		}
+	}
+
+	behavior := body.Item.ConflictBehavior
+	if behavior == "" {
+		behavior = conflictBehavior(r, conflictReplace)
+	}
+
+	var parent *item
+	var name string
+	if target.item != nil {
+		if target.item.isFolder {
+			errNotAFile.write(w)
+			return
+		}
+
+		parent, name = s.items[target.item.parentId], target.item.driveItem.Name
+	} else {
+		if !target.parent.isFolder {
+			errNotAFolder.write(w)
+			return
+		}
+
+		segments := target.missingSegments
+		parent, name = s.mkdirAll(target.parent, segments[:len(segments)-1]), segments[len(segments)-1]
+	}
+
+	if _, _, err := s.placeItem(parent, name, behavior); err != nil {
+		err.write(w)
+		return
+	}
+
+	sessionId := s.newResourceId("session")
+	session := &uploadSession{
+		parentId:           parent.driveItem.Id,
+		name:               name,
+		behavior:           behavior,
+		expirationDateTime: time.Now().UTC().Add(uploadSessionLifetime),
+	}
+	s.sessions[sessionId] = session
+
+	writeJSON(w, http.StatusOK, &uploadSessionStatus{
+		UploadURL:          s.server.URL + uploadPath + sessionId,
+		ExpirationDateTime: session.expirationDateTime.Format(time.RFC3339),
+		NextExpectedRanges: []string{"0-"},
+	})
+}
+
+// handleUploadSession serves the requests to the upload URL of an upload session, which upload the
+// fragments of the file, retrieve the status of the session or cancel the session.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createuploadsession?view=odsp-graph-online#upload-bytes-to-the-upload-session
+func (s *Server) handleUploadSession(w http.ResponseWriter, r *http.Request, sessionId string) {
+	session, ok := s.sessions[sessionId]
+	if ok && time.Now().After(session.expirationDateTime) {
+		delete(s.sessions, sessionId)
+		ok = false
+	}
+
+	if !ok {
+		errSessionExpired.write(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, session.status())
+	case http.MethodDelete:
+		delete(s.sessions, sessionId)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		s.uploadFragment(w, r, sessionId, session)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// uploadFragment appends a fragment to the file of an upload session, and creates the file once all its
+// fragments have been uploaded.
+func (s *Server) uploadFragment(w http.ResponseWriter, r *http.Request, sessionId string, session *uploadSession) {
+	fragment, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		errMalformedBody.write(w)
+		return
+	}
+
+	var first, last, totalSize int64
+	_, err = fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &first, &last, &totalSize)
+	if err != nil || first != int64(len(session.content)) || last-first+1 != int64(len(fragment)) || last >= totalSize {
+		errInvalidRange.write(w)
+		return
+	}
+
+	if session.totalSize != 0 && session.totalSize != totalSize {
+		errInvalidRange.write(w)
+		return
+	}
+
+	session.totalSize = totalSize
+	session.content = append(session.content, fragment...)
+	session.expirationDateTime = time.Now().UTC().Add(uploadSessionLifetime)
+
+	if int64(len(session.content)) < totalSize {
+		writeJSON(w, http.StatusAccepted, session.status())
+		return
+	}
+
+	delete(s.sessions, sessionId)
+
+	parent := s.items[session.parentId]
+	if !parent.exists() {
+		errItemNotFound.write(w)
+		return
+	}
+
+	s.writeFile(w, parent, session.name, session.behavior, session.content, "")
+}
+
+// status returns the status of the upload session.
+func (session *uploadSession) status() *uploadSessionStatus {
+	return &uploadSessionStatus{
+		ExpirationDateTime: session.expirationDateTime.Format(time.RFC3339),
+		NextExpectedRanges: []string{strconv.Itoa(len(session.content)) + "-"},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // PermissionService handles permission settings of a drive item
@@ -13,25 +14,113 @@ import (
 type PermissionService service
 
 // Permission is the permission of a drive item.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/permission?view=odsp-graph-online
 type Permission struct {
-	ID        string      `json:"id"`
-	GrantedTo interface{} `json:"grantedTo"`
-	Link      SharingLink `json:"link"`
-	Roles     []string    `json:"roles"`
+	ID                  string             `json:"id"`
+	GrantedTo           *IdentitySet       `json:"grantedTo"`
+	GrantedToIdentities []*IdentitySet     `json:"grantedToIdentities"`
+	Link                SharingLink        `json:"link"`
+	Roles               []string           `json:"roles"`
+	Invitation          *SharingInvitation `json:"invitation"`
+	InheritedFrom       *ParentReference   `json:"inheritedFrom"`
+	ShareId             string             `json:"shareId"`
+	ExpirationDateTime  string             `json:"expirationDateTime"`
+	HasPassword         bool               `json:"hasPassword"`
+}
+
+// IdentitySet is a keyed collection of the identities of an actor, e.g. the user who is granted a permission.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/identityset?view=odsp-graph-online
+type IdentitySet struct {
+	User        *Identity `json:"user"`
+	Application *Identity `json:"application"`
+	Device      *Identity `json:"device"`
+}
+
+// Identity represents an identity of an actor, for example, a user, device, or application.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/identity?view=odsp-graph-online
+type Identity struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+}
+
+// SharingInvitation represents the information about the invitation sent for a permission.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/sharinginvitation?view=odsp-graph-online
+type SharingInvitation struct {
+	Email          string       `json:"email"`
+	InvitedBy      *IdentitySet `json:"invitedBy"`
+	SignInRequired bool         `json:"signInRequired"`
+}
+
+// DriveRecipient represents a person, group, or other recipient to share a drive item with.
+// Either Email or ObjectId should be provided.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/driverecipient?view=odsp-graph-online
+type DriveRecipient struct {
+	Email    string `json:"email,omitempty"`
+	ObjectId string `json:"objectId,omitempty"`
+}
+
+// Invitation represents the information needed to invite recipients to access a drive item.
+type Invitation struct {
+	Recipients         []DriveRecipient
+	Roles              []PermissionRole
+	RequireSignIn      bool
+	SendInvitation     bool
+	Message            string    // Optional. A plain text formatted message that is included in the sharing invitation.
+	ExpirationDateTime time.Time // Optional. The date and time after which the permission expires.
+}
+
+// InviteRequest is the request for inviting recipients to access a drive item.
+type InviteRequest struct {
+	Recipients         []DriveRecipient `json:"recipients"`
+	Roles              []string         `json:"roles"`
+	RequireSignIn      bool             `json:"requireSignIn"`
+	SendInvitation     bool             `json:"sendInvitation"`
+	Message            string           `json:"message,omitempty"`
+	ExpirationDateTime string           `json:"expirationDateTime,omitempty"`
+}
+
+// InviteResponse is the response of inviting recipients to access a drive item.
+type InviteResponse struct {
+	Value []Permission `json:"value"`
 }
 
 // CreateShareLinkRequest is the request for creating a share link.
 type CreateShareLinkRequest struct {
-	Type  string `json:"type"`  // The type of sharing link to create. Either view, edit, or embed.
-	Scope string `json:"scope"` // Optional. The scope of link to create. Either anonymous or organization.
+	Type                       string `json:"type"`                                 // The type of sharing link to create, e.g. view, edit, or embed.
+	Scope                      string `json:"scope"`                                // Optional. The scope of link to create, e.g. anonymous, organization, or users.
+	Password                   string `json:"password,omitempty"`                   // Optional. The password of the sharing link.
+	ExpirationDateTime         string `json:"expirationDateTime,omitempty"`         // Optional. The expiration time of the sharing link.
+	RetainInheritedPermissions *bool  `json:"retainInheritedPermissions,omitempty"` // Optional. Whether to keep the inherited permissions of the item, true by default.
+}
+
+// ShareLinkOptions represents the optional settings of a new sharing link.
+type ShareLinkOptions struct {
+	Password                   string    // Only applicable to OneDrive personal.
+	ExpirationDateTime         time.Time // The date and time after which the sharing link expires.
+	RemoveInheritedPermissions bool      // If true, the existing inherited permissions are removed when sharing the item for the first time.
 }
 
 // SharingLink resource groups link-related data items into a single structure.
 // Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/sharinglink?view=odsp-graph-online
 type SharingLink struct {
-	Type  string `json:"type"`  // The type of sharing link to create. Either view, edit, or embed.
-	Scope string `json:"scope"` // Optional. The scope of link to create. Either anonymous or organization.
-	URL   string `json:"webUrl"`
+	Type             string `json:"type"`  // The type of sharing link, e.g. view, edit, or embed.
+	Scope            string `json:"scope"` // The scope of the link, e.g. anonymous, organization, or users.
+	URL              string `json:"webUrl"`
+	PreventsDownload bool   `json:"preventsDownload"`
+}
+
+// UpdatePermissionRequest is the request for updating a permission.
+type UpdatePermissionRequest struct {
+	Roles              []string `json:"roles,omitempty"`
+	ExpirationDateTime string   `json:"expirationDateTime,omitempty"`
+}
+
+// PermissionUpdate represents the changes to be made to a permission.
+// Only the non-empty properties will be updated.
+type PermissionUpdate struct {
+	Roles              []PermissionRole
+	ExpirationDateTime time.Time
 }
 
 // CreateShareLink will create a new sharing link if the specified link type doesn't already exist for the calling application.
@@ -39,9 +128,42 @@ type SharingLink struct {
 //
 // OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createlink?view=odsp-graph-online
 func (s *PermissionService) CreateShareLink(ctx context.Context, itemId string, permissionType ShareLinkType, permissionScope ShareLinkScope) (*Permission, error) {
-	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/createLink"
+	return s.CreateShareLinkWithOptions(ctx, "", itemId, permissionType, permissionScope, nil)
+}
+
+// CreateShareLinkWithOptions will create a new sharing link, just like CreateShareLink, with
+// additional settings such as password and expiration date time of the link.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_createlink?view=odsp-graph-online
+func (s *PermissionService) CreateShareLinkWithOptions(ctx context.Context, driveId string, itemId string,
+	permissionType ShareLinkType, permissionScope ShareLinkScope, options *ShareLinkOptions) (*Permission, error) {
+	if permissionType.toString() == "" {
+		return nil, errors.New("Please specify which type of sharing link to create.")
+	}
+
+	if permissionScope.toString() == "" {
+		return nil, errors.New("Please specify the scope of the sharing link.")
+	}
+
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId) + "/createLink"
 
 	body := &CreateShareLinkRequest{Type: permissionType.toString(), Scope: permissionScope.toString()}
+	if options != nil {
+		body.Password = options.Password
+
+		if options.RemoveInheritedPermissions {
+			retainInheritedPermissions := false
+			body.RetainInheritedPermissions = &retainInheritedPermissions
+		}
+
+		if !options.ExpirationDateTime.IsZero() {
+			body.ExpirationDateTime = options.ExpirationDateTime.UTC().Format(time.RFC3339)
+		}
+	}
+
 	req, err := s.client.NewRequest(http.MethodPost, apiURL, body)
 	if err != nil {
 		return nil, err
@@ -65,7 +187,17 @@ type ListPermissionsResponse struct {
 //
 // OneDrive API docs:  https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_permissions?view=odsp-graph-online
 func (s *PermissionService) List(ctx context.Context, itemId string) ([]Permission, error) {
-	apiURL := "me/drive/items/" + url.PathEscape(itemId) + "/permissions"
+	return s.ListInDrive(ctx, "", itemId)
+}
+
+// ListInDrive lists the effective sharing permissions of on a DriveItem in a drive which the authenticated user can access.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs:  https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_list_permissions?view=odsp-graph-online
+func (s *PermissionService) ListInDrive(ctx context.Context, driveId string, itemId string) ([]Permission, error) {
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId) + "/permissions"
 
 	req, err := s.client.NewRequest(http.MethodGet, apiURL, nil)
 	if err != nil {
@@ -93,10 +225,7 @@ func (s *PermissionService) Delete(ctx context.Context, driveId string, itemId s
 		return errors.New("Please provide the Item ID of the item to be deleted.")
 	}
 
-	apiURL := "me/drive/items/" + url.PathEscape(itemId)
-	if driveId != "" {
-		apiURL = "me/drives/" + url.PathEscape(driveId) + "/items/" + url.PathEscape(itemId)
-	}
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId)
 
 	apiURL += "/permissions/" + url.PathEscape(permissionId)
 
@@ -113,3 +242,115 @@ func (s *PermissionService) Delete(ctx context.Context, driveId string, itemId s
 
 	return nil
 }
+
+// Invite will send a sharing invitation for a drive item, which grants permissions for the specified recipients
+// and optionally sends an email to the recipients to notify them the item was shared.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_invite?view=odsp-graph-online
+func (s *PermissionService) Invite(ctx context.Context, driveId string, itemId string, invitation *Invitation) ([]Permission, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item to be shared.")
+	}
+
+	if invitation == nil || len(invitation.Recipients) == 0 {
+		return nil, errors.New("Please provide at least one recipient of the invitation.")
+	}
+
+	if len(invitation.Roles) == 0 {
+		return nil, errors.New("Please provide the roles to be granted to the recipients.")
+	}
+
+	for _, recipient := range invitation.Recipients {
+		if recipient.Email == "" && recipient.ObjectId == "" {
+			return nil, errors.New("Please provide either the email or the object ID of each recipient.")
+		}
+	}
+
+	body := &InviteRequest{
+		Recipients:     invitation.Recipients,
+		RequireSignIn:  invitation.RequireSignIn,
+		SendInvitation: invitation.SendInvitation,
+		Message:        invitation.Message,
+	}
+
+	for _, role := range invitation.Roles {
+		if role.toString() == "" {
+			return nil, errors.New("Please provide valid roles, either Read or Write.")
+		}
+
+		body.Roles = append(body.Roles, role.toString())
+	}
+
+	if !invitation.ExpirationDateTime.IsZero() {
+		body.ExpirationDateTime = invitation.ExpirationDateTime.UTC().Format(time.RFC3339)
+	}
+
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId) + "/invite"
+
+	req, err := s.client.NewRequest(http.MethodPost, apiURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *InviteResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse.Value, nil
+}
+
+// Update will update the roles or the expiration date time of a sharing permission of a file or folder.
+// Only sharing permissions that are not inherited can be updated. The inheritedFrom property must be null.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/permission_update?view=odsp-graph-online
+func (s *PermissionService) Update(ctx context.Context, driveId string, itemId string, permissionId string, update *PermissionUpdate) (*Permission, error) {
+	if itemId == "" {
+		return nil, errors.New("Please provide the Item ID of the item.")
+	}
+
+	if permissionId == "" {
+		return nil, errors.New("Please provide the ID of the permission to be updated.")
+	}
+
+	if update == nil || (len(update.Roles) == 0 && update.ExpirationDateTime.IsZero()) {
+		return nil, errors.New("Please provide the new roles or the new expiration date time of the permission.")
+	}
+
+	body := &UpdatePermissionRequest{}
+	for _, role := range update.Roles {
+		if role.toString() == "" {
+			return nil, errors.New("Please provide valid roles, either Read or Write.")
+		}
+
+		body.Roles = append(body.Roles, role.toString())
+	}
+
+	if !update.ExpirationDateTime.IsZero() {
+		body.ExpirationDateTime = update.ExpirationDateTime.UTC().Format(time.RFC3339)
+	}
+
+	apiURL := s.client.driveURL(driveId) + "/items/" + url.PathEscape(itemId)
+
+	apiURL += "/permissions/" + url.PathEscape(permissionId)
+
+	req, err := s.client.NewRequest(http.MethodPatch, apiURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *Permission
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestCreateSharingLink(t *testing.T) {
@@ -17,6 +18,7 @@ func TestCreateSharingLink(t *testing.T) {
 	jsonData := getTestDataFromFile(t, "fake_permission.json")
 	mux.HandleFunc("/me/drive/items/1/createLink", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"type":"view","scope":"anonymous"}`)
 
 		fmt.Fprint(w, string(jsonData))
 	})
@@ -64,3 +66,191 @@ func TestListPermissions(t *testing.T) {
 		t.Errorf("List returned %+v, want %+v", gotOneDriveResponse, wantDriveItem)
 	}
 }
+
+func TestInvite(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_invite.json")
+	mux.HandleFunc("/me/drive/items/1/invite", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var inviteRequest *InviteRequest
+		json.NewDecoder(r.Body).Decode(&inviteRequest)
+
+		wantInviteRequest := &InviteRequest{
+			Recipients:         []DriveRecipient{{Email: "robin@contoso.org"}, {ObjectId: "1234"}},
+			Roles:              []string{"write"},
+			RequireSignIn:      true,
+			SendInvitation:     true,
+			Message:            "Here's the file that we're collaborating on.",
+			ExpirationDateTime: "2018-07-15T14:00:00Z",
+		}
+		if !reflect.DeepEqual(inviteRequest, wantInviteRequest) {
+			t.Errorf("Request body = %+v, want %+v", inviteRequest, wantInviteRequest)
+		}
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	invitation := &Invitation{
+		Recipients:         []DriveRecipient{{Email: "robin@contoso.org"}, {ObjectId: "1234"}},
+		Roles:              []PermissionRole{Write},
+		RequireSignIn:      true,
+		SendInvitation:     true,
+		Message:            "Here's the file that we're collaborating on.",
+		ExpirationDateTime: time.Date(2018, 7, 15, 14, 0, 0, 0, time.UTC),
+	}
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DrivePermissions.Invite(ctx, "", "1", invitation)
+	if err != nil {
+		t.Errorf("Invite returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *InviteResponse
+	if err := json.Unmarshal(jsonData, &wantOneDriveResponse); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse.Value) {
+		t.Errorf("Invite returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse.Value)
+	}
+
+	if gotOneDriveResponse[0].GrantedToIdentities[0].User.Email != "robin@contoso.org" {
+		t.Errorf("Invite returned grantedToIdentities %+v, want the invited user", gotOneDriveResponse[0].GrantedToIdentities[0].User)
+	}
+}
+
+func TestInvite_withoutRecipients(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	ctx := context.Background()
+	_, err := client.DrivePermissions.Invite(ctx, "", "1", &Invitation{Roles: []PermissionRole{Read}})
+	if err == nil {
+		t.Errorf("There should be an error")
+	}
+}
+
+func TestInvite_invalidRole(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	invitation := &Invitation{
+		Recipients: []DriveRecipient{{Email: "robin@contoso.org"}},
+		Roles:      []PermissionRole{Read, PermissionRole(5)},
+	}
+
+	ctx := context.Background()
+	_, err := client.DrivePermissions.Invite(ctx, "", "1", invitation)
+	if err == nil {
+		t.Errorf("There should be an error")
+	}
+}
+
+func TestUpdatePermission_invalidRole(t *testing.T) {
+	client, _, _, teardown := setup()
+
+	defer teardown()
+
+	ctx := context.Background()
+	_, err := client.DrivePermissions.Update(ctx, "", "1", "2", &PermissionUpdate{Roles: []PermissionRole{PermissionRole(-1)}})
+	if err == nil {
+		t.Errorf("There should be an error")
+	}
+}
+
+func TestCreateSharingLink_invalidTypeOrScope(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1/createLink", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v for an invalid sharing link", r.URL)
+	})
+
+	ctx := context.Background()
+	if _, err := client.DrivePermissions.CreateShareLink(ctx, "1", ShareLinkType(10), Anonymous); err == nil {
+		t.Errorf("CreateShareLink with an invalid type returned no error")
+	}
+
+	if _, err := client.DrivePermissions.CreateShareLink(ctx, "1", View, ShareLinkScope(-1)); err == nil {
+		t.Errorf("CreateShareLink with an invalid scope returned no error")
+	}
+}
+
+func TestCreateSharingLinkWithOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_permission.json")
+	mux.HandleFunc("/drives/drive1/items/1/createLink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		testBody(t, r, `{"type":"blocksDownload","scope":"users","password":"ThisIsMyPrivatePassword","expirationDateTime":"2021-01-01T00:00:00Z","retainInheritedPermissions":false}`)
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	options := &ShareLinkOptions{
+		Password:                   "ThisIsMyPrivatePassword",
+		ExpirationDateTime:         time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		RemoveInheritedPermissions: true,
+	}
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DrivePermissions.CreateShareLinkWithOptions(ctx, "drive1", "1", BlocksDownload, Users, options)
+	if err != nil {
+		t.Errorf("CreateShareLinkWithOptions returned error: %v", err)
+	}
+
+	var wantDriveItem *Permission
+	if err := json.Unmarshal(jsonData, &wantDriveItem); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantDriveItem) {
+		t.Errorf("CreateShareLinkWithOptions returned %+v, want %+v", gotOneDriveResponse, wantDriveItem)
+	}
+}
+
+func TestUpdatePermission(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_permission.json")
+	mux.HandleFunc("/me/drive/items/1/permissions/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+
+		var updatePermissionRequest *UpdatePermissionRequest
+		json.NewDecoder(r.Body).Decode(&updatePermissionRequest)
+
+		wantUpdatePermissionRequest := &UpdatePermissionRequest{Roles: []string{"read"}}
+		if !reflect.DeepEqual(updatePermissionRequest, wantUpdatePermissionRequest) {
+			t.Errorf("Request body = %+v, want %+v", updatePermissionRequest, wantUpdatePermissionRequest)
+		}
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.DrivePermissions.Update(ctx, "", "1", "2", &PermissionUpdate{Roles: []PermissionRole{Read}})
+	if err != nil {
+		t.Errorf("Update returned error: %v", err)
+	}
+
+	var wantDriveItem *Permission
+	if err := json.Unmarshal(jsonData, &wantDriveItem); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantDriveItem) {
+		t.Errorf("Update returned %+v, want %+v", gotOneDriveResponse, wantDriveItem)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// PermissionRole the possible values for the roles property of Permission
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/permission?view=odsp-graph-online#roles-enumeration-values
+type PermissionRole int
+
+const (
+	Read PermissionRole = iota
+	Write
+)
+
+var permissionRoleNames = [...]string{"read", "write"}
+
+func (permissionRole PermissionRole) toString() string {
+	if permissionRole < 0 || int(permissionRole) >= len(permissionRoleNames) {
+		return ""
+	}
+
+	return permissionRoleNames[permissionRole]
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type responseKey struct{}
+
+// responseRecorder is the value stored in the context by WithResponse, which serializes the writes of the
+// response by the API calls sharing the context.
+type responseRecorder struct {
+	mu       sync.Mutex
+	response *Response
+}
+
+type clientRequestIdKey struct{}
+
+// Response represents the metadata of a response of the OneDrive API, i.e. the status and the headers of the
+// HTTP response, as well as the values of the headers which are useful for tracing and throttling.
+//
+// The body of the embedded HTTP response has already been read and closed, unless the response is the
+// content of a download stream, e.g. of DriveItems.DownloadStream, which is read through the returned reader.
+type Response struct {
+	*http.Response
+
+	// RequestId is the ID assigned to the request by the OneDrive API, from the request-id header.
+	// Microsoft support asks for it, along with ClientRequestId, when investigating a failed request.
+	RequestId string
+
+	// ClientRequestId is the ID of the request which is echoed by the OneDrive API, from the
+	// client-request-id header. See WithClientRequestId.
+	ClientRequestId string
+
+	// RetryAfter is the delay requested by the Retry-After header, e.g. when the request is throttled with
+	// a 429 or 503 status. Zero if there is no Retry-After header.
+	RetryAfter time.Duration
+
+	// RateLimit is the resource usage reported by the RateLimit headers, which are only sent by SharePoint
+	// Online and OneDrive for Business once the app has consumed at least 80% of its resource units.
+	RateLimit RateLimit
+}
+
+// RateLimit represents the values of the RateLimit headers of a response.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/sharepoint/dev/general-development/how-to-avoid-getting-throttled-or-blocked-in-sharepoint-online#ratelimit-headers
+type RateLimit struct {
+	// Limit is the number of resource units of the app within the current window. Zero if unknown.
+	Limit int
+
+	// Remaining is the number of resource units which the app can still consume within the current window.
+	Remaining int
+
+	// Reset is the time until the current window ends and the resource units are replenished.
+	Reset time.Duration
+}
+
+// WithResponse returns a copy of the context which stores the metadata of the responses of the API calls made
+// with the context in the given response. The response is overwritten by each HTTP response received, even
+// if the call returns an error, hence the response holds the metadata of the last HTTP response of the call,
+// e.g. the last status report of DriveItems.CopyAndWait.
+//
+// The context should be used for one API call at a time, and the response read once the call has returned.
+// If the context is shared by concurrent calls, e.g. by the goroutines of an errgroup, the writes of the
+// response do not race, but the response holds the metadata of whichever HTTP response was received last.
+//
+//	var resp onedrive.Response
+//	item, err := client.DriveItems.Get(onedrive.WithResponse(ctx, &resp), itemId)
+//	log.Printf("request-id: %s, client-request-id: %s", resp.RequestId, resp.ClientRequestId)
+func WithResponse(ctx context.Context, response *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, &responseRecorder{response: response})
+}
+
+// WithClientRequestId returns a copy of the context which sends the given ID in the client-request-id header
+// of the requests of the API calls made with the context, so that the requests can be traced with an ID
+// generated by the caller, e.g. the ID of a trace of the caller. The OneDrive API echoes the ID back in the
+// client-request-id header of the responses.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/best-practices-concept#reliability-and-support
+func WithClientRequestId(ctx context.Context, clientRequestId string) context.Context {
+	return context.WithValue(ctx, clientRequestIdKey{}, clientRequestId)
+}
+
+// setClientRequestId sets the client-request-id header of the request to the ID stored in the context, if any.
+func setClientRequestId(ctx context.Context, req *http.Request) {
+	if clientRequestId, ok := ctx.Value(clientRequestIdKey{}).(string); ok && clientRequestId != "" {
+		req.Header.Set("client-request-id", clientRequestId)
+		req.Header.Set("return-client-request-id", "true")
+	}
+}
+
+// recordResponse stores the metadata of the HTTP response in the response stored in the context, if any.
+func recordResponse(ctx context.Context, resp *http.Response) {
+	if recorder, ok := ctx.Value(responseKey{}).(*responseRecorder); ok && recorder.response != nil {
+		response := newResponse(resp)
+
+		recorder.mu.Lock()
+		*recorder.response = *response
+		recorder.mu.Unlock()
+	}
+}
+
+// newResponse returns the metadata of the given HTTP response.
+func newResponse(resp *http.Response) *Response {
+	response := &Response{
+		Response:        resp,
+		RequestId:       resp.Header.Get("request-id"),
+		ClientRequestId: resp.Header.Get("client-request-id"),
+	}
+
+	response.RetryAfter, _ = parseRetryAfter(resp)
+
+	response.RateLimit.Limit, _ = strconv.Atoi(resp.Header.Get("RateLimit-Limit"))
+	response.RateLimit.Remaining, _ = strconv.Atoi(resp.Header.Get("RateLimit-Remaining"))
+	if seconds, err := strconv.Atoi(resp.Header.Get("RateLimit-Reset")); err == nil && seconds > 0 {
+		response.RateLimit.Reset = time.Duration(seconds) * time.Second
+	}
+
+	return response
+}
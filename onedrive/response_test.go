@@ -0,0 +1,133 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithResponse_success(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "client-request-id", "trace-1")
+		testHeader(t, r, "return-client-request-id", "true")
+
+		w.Header().Set("request-id", "4d7c5a1e")
+		w.Header().Set("client-request-id", r.Header.Get("client-request-id"))
+		w.Header().Set("RateLimit-Limit", "1200")
+		w.Header().Set("RateLimit-Remaining", "180")
+		w.Header().Set("RateLimit-Reset", "30")
+
+		fmt.Fprint(w, `{"id": "1", "name": "Document.docx"}`)
+	})
+
+	var resp Response
+	ctx := WithResponse(WithClientRequestId(context.Background(), "trace-1"), &resp)
+
+	item, err := client.DriveItems.Get(ctx, "1")
+	if err != nil {
+		t.Fatalf("DriveItems.Get returned error: %v", err)
+	}
+
+	if item.Name != "Document.docx" {
+		t.Errorf("DriveItems.Get returned %+v", item)
+	}
+
+	if resp.Response == nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Response = %+v, want status %d", resp.Response, http.StatusOK)
+	}
+
+	if resp.RequestId != "4d7c5a1e" || resp.ClientRequestId != "trace-1" {
+		t.Errorf("Request IDs = %q, %q, want %q, %q", resp.RequestId, resp.ClientRequestId, "4d7c5a1e", "trace-1")
+	}
+
+	wantRateLimit := RateLimit{Limit: 1200, Remaining: 180, Reset: 30 * time.Second}
+	if resp.RateLimit != wantRateLimit {
+		t.Errorf("RateLimit = %+v, want %+v", resp.RateLimit, wantRateLimit)
+	}
+
+	if resp.RetryAfter != 0 {
+		t.Errorf("RetryAfter = %v, want 0", resp.RetryAfter)
+	}
+}
+
+func TestWithResponse_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("client-request-id") != "" {
+			t.Errorf("Request has client-request-id %q without WithClientRequestId", r.Header.Get("client-request-id"))
+		}
+
+		w.Header().Set("request-id", "9b0e3f2a")
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+
+		fmt.Fprint(w, `{"error": {"code": "activityLimitReached", "message": "The request has been throttled.", "innerError": {"date": "2020-11-08T10:00:00", "request-id": "9b0e3f2a"}}}`)
+	})
+
+	var resp Response
+	_, err := client.DriveItems.Get(WithResponse(context.Background(), &resp), "1")
+
+	var apiError *Error
+	if !errors.As(err, &apiError) {
+		t.Fatalf("DriveItems.Get returned error %v, want *Error", err)
+	}
+
+	if apiError.Code != "activityLimitReached" || apiError.InnerError.RequestId != "9b0e3f2a" {
+		t.Errorf("DriveItems.Get returned error %+v", apiError)
+	}
+
+	if want := "activityLimitReached - The request has been throttled. (2020-11-08T10:00:00)"; err.Error() != want {
+		t.Errorf("Error message = %q, want %q", err.Error(), want)
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests || resp.RequestId != "9b0e3f2a" || resp.RetryAfter != 10*time.Second {
+		t.Errorf("Response = status %d, request-id %q, Retry-After %v", resp.StatusCode, resp.RequestId, resp.RetryAfter)
+	}
+}
+
+func TestWithResponse_concurrentCalls(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/drive/items/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("request-id", "4d7c5a1e")
+		fmt.Fprint(w, `{"id": "1"}`)
+	})
+
+	var resp Response
+	ctx := WithResponse(context.Background(), &resp)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(itemId string) {
+			defer wg.Done()
+
+			if _, err := client.DriveItems.Get(ctx, itemId); err != nil {
+				t.Errorf("DriveItems.Get returned error: %v", err)
+			}
+		}(fmt.Sprint(i))
+	}
+	wg.Wait()
+
+	if resp.StatusCode != http.StatusOK || resp.RequestId != "4d7c5a1e" {
+		t.Errorf("Response = status %d, request-id %q", resp.StatusCode, resp.RequestId)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// MicrosoftSearchService handles communication with the Microsoft Search API, which searches drive items
+// with KQL (Keyword Query Language) queries, ranking, sorting and aggregations.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/search-api-overview?view=graph-rest-1.0
+type MicrosoftSearchService service
+
+// SearchQueryRequest represents the JSON object sent to the Microsoft Search API.
+type SearchQueryRequest struct {
+	Requests []*SearchRequest `json:"requests"`
+}
+
+// SearchRequest represents a search request of the Microsoft Search API.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/searchrequest?view=graph-rest-1.0
+type SearchRequest struct {
+	EntityTypes        []string             `json:"entityTypes"`
+	Query              *SearchQuery         `json:"query"`
+	Fields             []string             `json:"fields,omitempty"`
+	From               int                  `json:"from"`
+	Size               int                  `json:"size,omitempty"`
+	SortProperties     []*SortProperty      `json:"sortProperties,omitempty"`
+	Aggregations       []*AggregationOption `json:"aggregations,omitempty"`
+	AggregationFilters []string             `json:"aggregationFilters,omitempty"`
+	Region             string               `json:"region,omitempty"`
+}
+
+// SearchQuery represents the query terms of a search request.
+type SearchQuery struct {
+	QueryString string `json:"queryString"` // The KQL query, e.g. "contoso filetype:docx".
+}
+
+// SortProperty represents a property used to sort the search results.
+type SortProperty struct {
+	Name         string `json:"name"`
+	IsDescending bool   `json:"isDescending"`
+}
+
+// AggregationOption represents a property for which the search results are aggregated, e.g. by file type.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/aggregationoption?view=graph-rest-1.0
+type AggregationOption struct {
+	Field            string                       `json:"field"`
+	Size             int                          `json:"size,omitempty"`
+	BucketDefinition *BucketAggregationDefinition `json:"bucketDefinition"`
+}
+
+// BucketAggregationDefinition represents how the buckets of an aggregation are sorted and filtered.
+type BucketAggregationDefinition struct {
+	SortBy       string `json:"sortBy"` // Either count, keyAsString or keyAsNumber.
+	IsDescending bool   `json:"isDescending"`
+	MinimumCount int    `json:"minimumCount,omitempty"`
+}
+
+// SearchQueryResponse represents the JSON object returned by the Microsoft Search API.
+type SearchQueryResponse struct {
+	Value []*SearchResponse `json:"value"`
+}
+
+// SearchResponse represents the results of a search request.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/searchresponse?view=graph-rest-1.0
+type SearchResponse struct {
+	SearchTerms    []string               `json:"searchTerms"`
+	HitsContainers []*SearchHitsContainer `json:"hitsContainers"`
+}
+
+// SearchHitsContainer represents a page of the search results and the aggregations of all the search results.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/searchhitscontainer?view=graph-rest-1.0
+type SearchHitsContainer struct {
+	Hits                 []*SearchHit         `json:"hits"`
+	Total                int                  `json:"total"`
+	MoreResultsAvailable bool                 `json:"moreResultsAvailable"`
+	Aggregations         []*SearchAggregation `json:"aggregations"`
+}
+
+// SearchHit represents a single search result. The summary contains the matched terms highlighted with <c0></c0> tags.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/searchhit?view=graph-rest-1.0
+type SearchHit struct {
+	HitId    string     `json:"hitId"`
+	Rank     int        `json:"rank"`
+	Summary  string     `json:"summary"`
+	Resource *DriveItem `json:"resource"`
+}
+
+// SearchAggregation represents the buckets of an aggregation of the search results.
+type SearchAggregation struct {
+	Field   string          `json:"field"`
+	Buckets []*SearchBucket `json:"buckets"`
+}
+
+// SearchBucket represents a bucket of an aggregation. The aggregation filter token can be used in
+// AggregationFilters of a subsequent search request to narrow the search results down to the bucket.
+type SearchBucket struct {
+	Key                    string `json:"key"`
+	Count                  int    `json:"count"`
+	AggregationFilterToken string `json:"aggregationFilterToken"`
+}
+
+// SearchQueryOptions represents the optional settings of a search query.
+type SearchQueryOptions struct {
+	EntityTypes        []SearchEntityType // The types of the resources to be searched. Defaults to drive items.
+	Fields             []string           // The properties of the resources to be returned.
+	From               int                // The zero-based index of the first search result to be returned.
+	Size               int                // The number of search results to be returned, up to 500.
+	SortProperties     []*SortProperty
+	Aggregations       []*AggregationOption
+	AggregationFilters []string
+	Region             string // Required when searching with application permissions, e.g. "NAM".
+}
+
+// Query searches the drive items, or the list items, with the given KQL query string.
+//
+// The search results are paged with From and Size of the options. When MoreResultsAvailable of the
+// returned hits container is true, the next page can be retrieved by increasing From by Size.
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/search-concept-files
+func (s *MicrosoftSearchService) Query(ctx context.Context, queryString string, options *SearchQueryOptions) (*SearchResponse, error) {
+	if queryString == "" {
+		return nil, errors.New("Please provide the query string.")
+	}
+
+	if options == nil {
+		options = &SearchQueryOptions{}
+	}
+
+	searchRequest := &SearchRequest{
+		Query:              &SearchQuery{QueryString: queryString},
+		Fields:             options.Fields,
+		From:               options.From,
+		Size:               options.Size,
+		SortProperties:     options.SortProperties,
+		Aggregations:       options.Aggregations,
+		AggregationFilters: options.AggregationFilters,
+		Region:             options.Region,
+	}
+
+	for _, entityType := range options.EntityTypes {
+		if entityType.toString() == "" {
+			return nil, errors.New("Please provide valid entity types, either DriveItemEntity or ListItemEntity.")
+		}
+
+		searchRequest.EntityTypes = append(searchRequest.EntityTypes, entityType.toString())
+	}
+
+	if len(searchRequest.EntityTypes) == 0 {
+		searchRequest.EntityTypes = []string{DriveItemEntity.toString()}
+	}
+
+	body := &SearchQueryRequest{Requests: []*SearchRequest{searchRequest}}
+
+	req, err := s.client.NewRequest(http.MethodPost, "search/query", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *SearchQueryResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(oneDriveResponse.Value) == 0 {
+		return &SearchResponse{}, nil
+	}
+
+	return oneDriveResponse.Value[0], nil
+}
+
+// DriveItems returns the drive items of all the search hits in the hits containers.
+func (r *SearchResponse) DriveItems() []*DriveItem {
+	var driveItems []*DriveItem
+	for _, hitsContainer := range r.HitsContainers {
+		for _, hit := range hitsContainer.Hits {
+			if hit.Resource != nil {
+				driveItems = append(driveItems, hit.Resource)
+			}
+		}
+	}
+
+	return driveItems
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMicrosoftSearchService_Query(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_searchQuery.json")
+	mux.HandleFunc("/search/query", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var searchQueryRequest *SearchQueryRequest
+		json.NewDecoder(r.Body).Decode(&searchQueryRequest)
+
+		wantSearchQueryRequest := &SearchQueryRequest{
+			Requests: []*SearchRequest{
+				{
+					EntityTypes:    []string{"driveItem", "listItem"},
+					Query:          &SearchQuery{QueryString: "contoso filetype:docx OR filetype:pdf"},
+					Fields:         []string{"name", "lastModifiedDateTime"},
+					From:           25,
+					Size:           25,
+					SortProperties: []*SortProperty{{Name: "lastModifiedDateTime", IsDescending: true}},
+					Aggregations: []*AggregationOption{
+						{
+							Field:            "FileType",
+							Size:             10,
+							BucketDefinition: &BucketAggregationDefinition{SortBy: "count", IsDescending: true, MinimumCount: 1},
+						},
+					},
+				},
+			},
+		}
+		if !reflect.DeepEqual(searchQueryRequest, wantSearchQueryRequest) {
+			t.Errorf("Request body = %+v, want %+v", searchQueryRequest, wantSearchQueryRequest)
+		}
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	options := &SearchQueryOptions{
+		EntityTypes:    []SearchEntityType{DriveItemEntity, ListItemEntity},
+		Fields:         []string{"name", "lastModifiedDateTime"},
+		From:           25,
+		Size:           25,
+		SortProperties: []*SortProperty{{Name: "lastModifiedDateTime", IsDescending: true}},
+		Aggregations: []*AggregationOption{
+			{
+				Field:            "FileType",
+				Size:             10,
+				BucketDefinition: &BucketAggregationDefinition{SortBy: "count", IsDescending: true, MinimumCount: 1},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	gotSearchResponse, err := client.Search.Query(ctx, "contoso filetype:docx OR filetype:pdf", options)
+	if err != nil {
+		t.Fatalf("Search.Query returned error: %v", err)
+	}
+
+	var wantSearchQueryResponse *SearchQueryResponse
+	json.Unmarshal(jsonData, &wantSearchQueryResponse)
+
+	if !reflect.DeepEqual(gotSearchResponse, wantSearchQueryResponse.Value[0]) {
+		t.Errorf("Search.Query returned %+v, want %+v", gotSearchResponse, wantSearchQueryResponse.Value[0])
+	}
+
+	hit := gotSearchResponse.HitsContainers[0].Hits[0]
+	if hit.Summary != "<c0>Contoso</c0> Detailed Design <ddd/>" || hit.Resource.Name != "Contoso Detailed Design.docx" {
+		t.Errorf("Search.Query returned hit %+v, want the highlighted drive item", hit)
+	}
+
+	if driveItems := gotSearchResponse.DriveItems(); len(driveItems) != 1 || driveItems[0].ParentReference.DriveId != "b!Ybt8PQkSL0qsPLjbJ1s3zB0M1TOlNPFGsSz" {
+		t.Errorf("SearchResponse.DriveItems returned %+v, want the drive item of the hit", driveItems)
+	}
+}
+
+func TestMicrosoftSearchService_Query_defaultEntityType(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/search/query", func(w http.ResponseWriter, r *http.Request) {
+		var searchQueryRequest *SearchQueryRequest
+		json.NewDecoder(r.Body).Decode(&searchQueryRequest)
+
+		if got := searchQueryRequest.Requests[0].EntityTypes; !reflect.DeepEqual(got, []string{"driveItem"}) {
+			t.Errorf("Request entity types = %v, want [driveItem]", got)
+		}
+
+		fmt.Fprint(w, `{"value": []}`)
+	})
+
+	ctx := context.Background()
+	gotSearchResponse, err := client.Search.Query(ctx, "contoso", nil)
+	if err != nil {
+		t.Fatalf("Search.Query returned error: %v", err)
+	}
+
+	if len(gotSearchResponse.DriveItems()) != 0 {
+		t.Errorf("Search.Query returned %+v, want no search results", gotSearchResponse)
+	}
+}
+
+func TestMicrosoftSearchService_Query_invalidEntityType(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/search/query", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v for an invalid entity type", r.URL)
+	})
+
+	ctx := context.Background()
+	_, err := client.Search.Query(ctx, "contoso", &SearchQueryOptions{EntityTypes: []SearchEntityType{SearchEntityType(7)}})
+	if err == nil {
+		t.Errorf("Search.Query with an invalid entity type returned no error")
+	}
+}
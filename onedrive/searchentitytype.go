@@ -0,0 +1,25 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+// SearchEntityType the possible values for the entity types of a search request
+//
+// Microsoft Graph API docs: https://docs.microsoft.com/en-us/graph/api/resources/searchrequest?view=graph-rest-1.0#entitytype-values
+type SearchEntityType int
+
+const (
+	DriveItemEntity SearchEntityType = iota
+	ListItemEntity
+)
+
+var searchEntityTypeNames = [...]string{"driveItem", "listItem"}
+
+func (searchEntityType SearchEntityType) toString() string {
+	if searchEntityType < 0 || int(searchEntityType) >= len(searchEntityTypeNames) {
+		return ""
+	}
+
+	return searchEntityTypeNames[searchEntityType]
+}
@@ -12,8 +12,15 @@ type ShareLinkScope int
 const (
 	Anonymous ShareLinkScope = iota
 	Organization
+	Users
 )
 
+var shareLinkScopeNames = [...]string{"anonymous", "organization", "users"}
+
 func (shareLinkScope ShareLinkScope) toString() string {
-	return [...]string{"anonymous", "organization"}[shareLinkScope]
+	if shareLinkScope < 0 || int(shareLinkScope) >= len(shareLinkScopeNames) {
+		return ""
+	}
+
+	return shareLinkScopeNames[shareLinkScope]
 }
@@ -13,8 +13,18 @@ const (
 	View ShareLinkType = iota
 	Edit
 	Embed
+	BlocksDownload
+	CreateOnly
+	AddressBar
+	AdminDefault
 )
 
+var shareLinkTypeNames = [...]string{"view", "edit", "embed", "blocksDownload", "createOnly", "addressBar", "adminDefault"}
+
 func (shareLinkType ShareLinkType) toString() string {
-	return [...]string{"view", "edit", "embed"}[shareLinkType]
+	if shareLinkType < 0 || int(shareLinkType) >= len(shareLinkTypeNames) {
+		return ""
+	}
+
+	return shareLinkTypeNames[shareLinkType]
 }
@@ -0,0 +1,168 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SharesService handles communication with the shared items related methods of the OneDrive API.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/shares_get?view=odsp-graph-online
+type SharesService service
+
+// SharedDriveItem represents a drive item which has been shared.
+// Ref: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/shareddriveitem?view=odsp-graph-online
+type SharedDriveItem struct {
+	Id        string       `json:"id"`
+	Name      string       `json:"name"`
+	Owner     *IdentitySet `json:"owner"`
+	DriveItem *DriveItem   `json:"driveItem"`
+	Root      *DriveItem   `json:"root"`
+}
+
+// EncodeSharingURL encodes a sharing URL into a share ID which can be used to access the shared item
+// with the Shares API, i.e. "u!" followed by the unpadded base64url encoding of the sharing URL.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/shares_get?view=odsp-graph-online#encoding-sharing-urls
+func EncodeSharingURL(sharingURL string) string {
+	return "u!" + base64.RawURLEncoding.EncodeToString([]byte(strings.TrimSpace(sharingURL)))
+}
+
+// toShareId returns the share ID for the given share ID or sharing URL.
+func toShareId(shareIdOrURL string) (string, error) {
+	shareIdOrURL = strings.TrimSpace(shareIdOrURL)
+	if shareIdOrURL == "" {
+		return "", errors.New("Please provide the sharing URL or the share ID of the shared item.")
+	}
+
+	if strings.HasPrefix(shareIdOrURL, "u!") || strings.HasPrefix(shareIdOrURL, "s!") {
+		return shareIdOrURL, nil
+	}
+
+	if !strings.HasPrefix(shareIdOrURL, "https://") && !strings.HasPrefix(shareIdOrURL, "http://") {
+		return "", errors.New("The given value is neither a share ID nor a sharing URL.")
+	}
+
+	return EncodeSharingURL(shareIdOrURL), nil
+}
+
+// newShareRequest creates an API request to the Shares API for the given share ID or sharing URL.
+func (s *SharesService) newShareRequest(shareIdOrURL string, relativePath string, redeem bool) (*http.Request, error) {
+	shareId, err := toShareId(shareIdOrURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", "shares/"+url.PathEscape(shareId)+relativePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if redeem {
+		// Grant the caller durable access to the shared item instead of access through the sharing link only.
+		req.Header.Set("Prefer", "redeemSharingLink")
+	}
+
+	return req, nil
+}
+
+// Get the shared item of a sharing URL or a share ID.
+//
+// If redeem is true, the sharing link will be redeemed so that the authenticated user will be granted
+// durable access to the shared item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/shares_get?view=odsp-graph-online
+func (s *SharesService) Get(ctx context.Context, shareIdOrURL string, redeem bool) (*SharedDriveItem, error) {
+	req, err := s.newShareRequest(shareIdOrURL, "", redeem)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedDriveItem *SharedDriveItem
+	err = s.client.Do(ctx, req, false, &sharedDriveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedDriveItem, nil
+}
+
+// GetDriveItem gets the underlying drive item of a sharing URL or a share ID.
+//
+// If redeem is true, the sharing link will be redeemed so that the authenticated user will be granted
+// durable access to the shared item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/shares_get?view=odsp-graph-online#access-the-shared-item-directly
+func (s *SharesService) GetDriveItem(ctx context.Context, shareIdOrURL string, redeem bool) (*DriveItem, error) {
+	req, err := s.newShareRequest(shareIdOrURL, "/driveItem", redeem)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// GetRoot gets the root folder of the shared items of a sharing URL or a share ID.
+//
+// If redeem is true, the sharing link will be redeemed so that the authenticated user will be granted
+// durable access to the shared item.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/shares_get?view=odsp-graph-online#response
+func (s *SharesService) GetRoot(ctx context.Context, shareIdOrURL string, redeem bool) (*DriveItem, error) {
+	req, err := s.newShareRequest(shareIdOrURL, "/root", redeem)
+	if err != nil {
+		return nil, err
+	}
+
+	var driveItem *DriveItem
+	err = s.client.Do(ctx, req, false, &driveItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return driveItem, nil
+}
+
+// ListChildren lists the items of a shared folder of a sharing URL or a share ID.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/shares_get?view=odsp-graph-online#access-the-shared-item-directly
+func (s *SharesService) ListChildren(ctx context.Context, shareIdOrURL string) (*OneDriveDriveItemsResponse, error) {
+	req, err := s.newShareRequest(shareIdOrURL, "/driveItem/children", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDriveItemsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// Download downloads the content of a shared file of a sharing URL or a share ID.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/driveitem_get_content?view=odsp-graph-online
+func (s *SharesService) Download(ctx context.Context, shareIdOrURL string) ([]byte, error) {
+	req, err := s.newShareRequest(shareIdOrURL, "/driveItem/content", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.download(ctx, req)
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeSharingURL(t *testing.T) {
+	testCases := []struct {
+		sharingURL  string
+		wantShareId string
+	}{
+		{
+			// No padding is needed.
+			sharingURL:  "https://onedrive.live.com/redir?resid=1231244193912!12&authKey=1201919!12921!1",
+			wantShareId: "u!aHR0cHM6Ly9vbmVkcml2ZS5saXZlLmNvbS9yZWRpcj9yZXNpZD0xMjMxMjQ0MTkzOTEyITEyJmF1dGhLZXk9MTIwMTkxOSExMjkyMSEx",
+		},
+		{
+			// Padding "==" is removed and "+" is replaced with "-".
+			sharingURL:  "https://1drv.ms/f/s!Ao?~~",
+			wantShareId: "u!aHR0cHM6Ly8xZHJ2Lm1zL2YvcyFBbz9-fg",
+		},
+		{
+			// Padding "=" is removed and "/" is replaced with "_".
+			sharingURL:  "https://a.b/?????",
+			wantShareId: "u!aHR0cHM6Ly9hLmIvPz8_Pz8",
+		},
+		{
+			// Surrounding whitespaces are not part of the sharing URL.
+			sharingURL:  " https://1drv.ms/u/s!AB?\n",
+			wantShareId: "u!aHR0cHM6Ly8xZHJ2Lm1zL3UvcyFBQj8",
+		},
+	}
+
+	for _, testCase := range testCases {
+		if gotShareId := EncodeSharingURL(testCase.sharingURL); gotShareId != testCase.wantShareId {
+			t.Errorf("EncodeSharingURL(%q) returned %q, want %q", testCase.sharingURL, gotShareId, testCase.wantShareId)
+		}
+	}
+}
+
+func TestToShareId(t *testing.T) {
+	testCases := []struct {
+		shareIdOrURL string
+		wantShareId  string
+		wantError    bool
+	}{
+		{shareIdOrURL: "u!aHR0cHM6Ly8xZHJ2Lm1zL3UvcyFBQj8", wantShareId: "u!aHR0cHM6Ly8xZHJ2Lm1zL3UvcyFBQj8"},
+		{shareIdOrURL: "s!AtuAM_NacwVahiFpuMGTNzdF", wantShareId: "s!AtuAM_NacwVahiFpuMGTNzdF"},
+		{shareIdOrURL: "https://1drv.ms/u/s!AB?", wantShareId: "u!aHR0cHM6Ly8xZHJ2Lm1zL3UvcyFBQj8"},
+		{shareIdOrURL: "", wantError: true},
+		{shareIdOrURL: "   ", wantError: true},
+		{shareIdOrURL: "1drv.ms/u/s!AB", wantError: true},
+	}
+
+	for _, testCase := range testCases {
+		gotShareId, err := toShareId(testCase.shareIdOrURL)
+		if testCase.wantError {
+			if err == nil {
+				t.Errorf("toShareId(%q) should return an error", testCase.shareIdOrURL)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("toShareId(%q) returned error: %v", testCase.shareIdOrURL, err)
+		}
+
+		if gotShareId != testCase.wantShareId {
+			t.Errorf("toShareId(%q) returned %q, want %q", testCase.shareIdOrURL, gotShareId, testCase.wantShareId)
+		}
+	}
+}
+
+func TestSharesService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_sharedDriveItem.json")
+	mux.HandleFunc("/shares/u!aHR0cHM6Ly8xZHJ2Lm1zL3UvcyFBQj8", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Prefer", "redeemSharingLink")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotSharedDriveItem, err := client.Shares.Get(ctx, "https://1drv.ms/u/s!AB?", true)
+	if err != nil {
+		t.Errorf("Shares.Get returned error: %v", err)
+	}
+
+	var wantSharedDriveItem *SharedDriveItem
+	json.Unmarshal(jsonData, &wantSharedDriveItem)
+
+	if !reflect.DeepEqual(gotSharedDriveItem, wantSharedDriveItem) {
+		t.Errorf("Shares.Get returned %+v, want %+v", gotSharedDriveItem, wantSharedDriveItem)
+	}
+}
+
+func TestSharesService_GetDriveItem(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_driveItem.json")
+	mux.HandleFunc("/shares/s!AtuAM_NacwVahiFpuMGTNzdF/driveItem", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Prefer", "")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotDriveItem, err := client.Shares.GetDriveItem(ctx, "s!AtuAM_NacwVahiFpuMGTNzdF", false)
+	if err != nil {
+		t.Errorf("Shares.GetDriveItem returned error: %v", err)
+	}
+
+	var wantDriveItem *DriveItem
+	json.Unmarshal(jsonData, &wantDriveItem)
+
+	if !reflect.DeepEqual(gotDriveItem, wantDriveItem) {
+		t.Errorf("Shares.GetDriveItem returned %+v, want %+v", gotDriveItem, wantDriveItem)
+	}
+}
+
+func TestSharesService_ListChildren(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_driveItems.json")
+	mux.HandleFunc("/shares/s!AtuAM_NacwVahiFpuMGTNzdF/driveItem/children", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Shares.ListChildren(ctx, "s!AtuAM_NacwVahiFpuMGTNzdF")
+	if err != nil {
+		t.Errorf("Shares.ListChildren returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveDriveItemsResponse
+	json.Unmarshal(jsonData, &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Shares.ListChildren returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestSharesService_Download(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	client.client = &http.Client{Transport: authorizingTransport{}}
+
+	mux.HandleFunc("/shares/s!AtuAM_NacwVahiFpuMGTNzdF/driveItem/content", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "Bearer token")
+
+		http.Redirect(w, r, "/api/download/1", http.StatusFound)
+	})
+
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "")
+
+		fmt.Fprint(w, "Hello, World!")
+	})
+
+	ctx := context.Background()
+	gotContent, err := client.Shares.Download(ctx, "s!AtuAM_NacwVahiFpuMGTNzdF")
+	if err != nil {
+		t.Errorf("Shares.Download returned error: %v", err)
+	}
+
+	if string(gotContent) != "Hello, World!" {
+		t.Errorf("Shares.Download returned %q, want %q", gotContent, "Hello, World!")
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SitesService handles communication with the SharePoint sites related methods of the OneDrive API.
+//
+// The drives of a site are its document libraries. Their IDs can be used as the driveId of the
+// drive item methods, so that the items in the document libraries can be managed just like the
+// items in the default drive of the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/resources/site?view=odsp-graph-online
+type SitesService service
+
+// OneDriveSitesResponse represents the JSON object containing site list returned by the OneDrive API.
+type OneDriveSitesResponse struct {
+	ODataContext string  `json:"@odata.context"`
+	NextLink     string  `json:"@odata.nextLink"`
+	Sites        []*Site `json:"value"`
+}
+
+// Site represents a SharePoint site.
+type Site struct {
+	Id                   string          `json:"id"`
+	Name                 string          `json:"name"`
+	DisplayName          string          `json:"displayName"`
+	Description          string          `json:"description"`
+	WebURL               string          `json:"webUrl"`
+	CreatedDateTime      time.Time       `json:"createdDateTime"`
+	LastModifiedDateTime time.Time       `json:"lastModifiedDateTime"`
+	SiteCollection       *SiteCollection `json:"siteCollection,omitempty"`
+}
+
+// SiteCollection represents the site collection which a site belongs to.
+type SiteCollection struct {
+	Hostname string `json:"hostname"`
+}
+
+// Get a SharePoint site by its ID, e.g. "contoso.sharepoint.com,{site-collection-id},{web-id}".
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/site_get?view=odsp-graph-online
+func (s *SitesService) Get(ctx context.Context, siteId string) (*Site, error) {
+	if siteId == "" {
+		return nil, errors.New("Please provide the ID of the site.")
+	}
+
+	return s.get(ctx, "sites/"+url.PathEscape(siteId))
+}
+
+// GetRoot gets the root SharePoint site of the tenant of the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/site_get?view=odsp-graph-online#access-the-root-site
+func (s *SitesService) GetRoot(ctx context.Context) (*Site, error) {
+	return s.get(ctx, "sites/root")
+}
+
+// GetByPath gets a SharePoint site by its hostname, e.g. "contoso.sharepoint.com", and its
+// server-relative path, e.g. "/sites/marketing".
+//
+// If serverRelativePath is empty, it means the root site of the hostname will be returned.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/site_get?view=odsp-graph-online#access-a-site-by-server-relative-url
+func (s *SitesService) GetByPath(ctx context.Context, hostname string, serverRelativePath string) (*Site, error) {
+	if hostname == "" {
+		return nil, errors.New("Please provide the hostname of the site.")
+	}
+
+	apiURL := "sites/" + url.PathEscape(hostname)
+
+	serverRelativePath = strings.Trim(serverRelativePath, "/")
+	if serverRelativePath != "" {
+		var escapedSegments []string
+		for _, segment := range strings.Split(serverRelativePath, "/") {
+			escapedSegments = append(escapedSegments, url.PathEscape(segment))
+		}
+
+		apiURL += ":/" + strings.Join(escapedSegments, "/")
+	}
+
+	return s.get(ctx, apiURL)
+}
+
+func (s *SitesService) get(ctx context.Context, apiURL string) (*Site, error) {
+	req, err := s.client.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var site *Site
+	err = s.client.Do(ctx, req, false, &site)
+	if err != nil {
+		return nil, err
+	}
+
+	return site, nil
+}
+
+// Search the SharePoint sites in the tenant of the authenticated user with the given keywords.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/site_search?view=odsp-graph-online
+func (s *SitesService) Search(ctx context.Context, query string) (*OneDriveSitesResponse, error) {
+	return s.list(ctx, "sites?search="+url.QueryEscape(query))
+}
+
+// ListFollowed lists the SharePoint sites followed by the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/sites-list-followed?view=graph-rest-1.0
+func (s *SitesService) ListFollowed(ctx context.Context) (*OneDriveSitesResponse, error) {
+	return s.list(ctx, s.client.userURL+"/followedSites")
+}
+
+func (s *SitesService) list(ctx context.Context, apiURL string) (*OneDriveSitesResponse, error) {
+	req, err := s.client.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveSitesResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// GetDefaultDrive gets the default document library of a SharePoint site.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_get?view=odsp-graph-online#get-the-document-library-for-a-site
+func (s *SitesService) GetDefaultDrive(ctx context.Context, siteId string) (*Drive, error) {
+	if siteId == "" {
+		return nil, errors.New("Please provide the ID of the site.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, "sites/"+url.PathEscape(siteId)+"/drive", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var drive *Drive
+	err = s.client.Do(ctx, req, false, &drive)
+	if err != nil {
+		return nil, err
+	}
+
+	return drive, nil
+}
+
+// ListDrives lists the document libraries of a SharePoint site.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/drive_list?view=odsp-graph-online#list-a-sites-drives
+func (s *SitesService) ListDrives(ctx context.Context, siteId string) (*OneDriveDrivesResponse, error) {
+	if siteId == "" {
+		return nil, errors.New("Please provide the ID of the site.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, "sites/"+url.PathEscape(siteId)+"/drives", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveDrivesResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
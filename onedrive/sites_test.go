@@ -0,0 +1,210 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testSiteId = "contoso.sharepoint.com,2C712604-1370-44E7-A1F5-426573FDA80A,2D2244C3-251A-49EA-93A8-39E1C3A060FE"
+
+func TestSitesService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_site.json")
+	mux.HandleFunc("/sites/"+testSiteId, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotSite, err := client.Sites.Get(ctx, testSiteId)
+	if err != nil {
+		t.Fatalf("Sites.Get returned error: %v", err)
+	}
+
+	var wantSite *Site
+	json.Unmarshal(jsonData, &wantSite)
+
+	if !reflect.DeepEqual(gotSite, wantSite) {
+		t.Errorf("Sites.Get returned %+v, want %+v", gotSite, wantSite)
+	}
+
+	if _, err := client.Sites.Get(ctx, ""); err == nil {
+		t.Errorf("Sites.Get returned no error for an empty site ID")
+	}
+}
+
+func TestSitesService_GetRoot(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/sites/root", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_site.json")))
+	})
+
+	ctx := context.Background()
+	gotSite, err := client.Sites.GetRoot(ctx)
+	if err != nil {
+		t.Fatalf("Sites.GetRoot returned error: %v", err)
+	}
+
+	if gotSite.Id != testSiteId {
+		t.Errorf("Sites.GetRoot returned site %q, want %q", gotSite.Id, testSiteId)
+	}
+}
+
+func TestSitesService_GetByPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/sites/contoso.sharepoint.com:/sites/marketing team", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.EscapedPath(), "/sites/contoso.sharepoint.com:/sites/marketing%20team"; got != want {
+			t.Errorf("Request path = %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_site.json")))
+	})
+
+	ctx := context.Background()
+	gotSite, err := client.Sites.GetByPath(ctx, "contoso.sharepoint.com", "/sites/marketing team/")
+	if err != nil {
+		t.Fatalf("Sites.GetByPath returned error: %v", err)
+	}
+
+	if gotSite.SiteCollection.Hostname != "contoso.sharepoint.com" {
+		t.Errorf("Sites.GetByPath returned %+v, want the site in contoso.sharepoint.com", gotSite)
+	}
+
+	if _, err := client.Sites.GetByPath(ctx, "", "/sites/marketing"); err == nil {
+		t.Errorf("Sites.GetByPath returned no error for an empty hostname")
+	}
+}
+
+func TestSitesService_Search(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_sites.json")
+	mux.HandleFunc("/sites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testFormValues(t, r, map[string]string{"search": "marketing & sales"})
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Sites.Search(ctx, "marketing & sales")
+	if err != nil {
+		t.Fatalf("Sites.Search returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveSitesResponse
+	json.Unmarshal(jsonData, &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Sites.Search returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestSitesService_ListFollowed(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/me/followedSites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_sites.json")))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Sites.ListFollowed(ctx)
+	if err != nil {
+		t.Fatalf("Sites.ListFollowed returned error: %v", err)
+	}
+
+	if len(gotOneDriveResponse.Sites) != 2 {
+		t.Errorf("Sites.ListFollowed returned %d sites, want 2", len(gotOneDriveResponse.Sites))
+	}
+}
+
+func TestSitesService_ListDrives(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_siteDrives.json")
+	mux.HandleFunc("/sites/"+testSiteId+"/drives", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Sites.ListDrives(ctx, testSiteId)
+	if err != nil {
+		t.Fatalf("Sites.ListDrives returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveDrivesResponse
+	json.Unmarshal(jsonData, &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Sites.ListDrives returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestSitesService_driveItemsInDocumentLibrary(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/sites/"+testSiteId+"/drive", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"id": "b!BCZxLHATx0Sh9UJlc_2oCsNEIi0aJepJk6g54cOgYP4", "name": "Documents", "driveType": "documentLibrary"}`)
+	})
+
+	mux.HandleFunc("/drives/b!BCZxLHATx0Sh9UJlc_2oCsNEIi0aJepJk6g54cOgYP4/items/1/permissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(getTestDataFromFile(t, "fake_permissions.json")))
+	})
+
+	ctx := context.Background()
+	gotDrive, err := client.Sites.GetDefaultDrive(ctx, testSiteId)
+	if err != nil {
+		t.Fatalf("Sites.GetDefaultDrive returned error: %v", err)
+	}
+
+	if gotDrive.Name != "Documents" || gotDrive.DriveType != "documentLibrary" {
+		t.Errorf("Sites.GetDefaultDrive returned %+v, want the Documents library", gotDrive)
+	}
+
+	gotPermissions, err := client.DrivePermissions.ListInDrive(ctx, gotDrive.Id, "1")
+	if err != nil {
+		t.Fatalf("DrivePermissions.ListInDrive returned error: %v", err)
+	}
+
+	if len(gotPermissions) == 0 {
+		t.Errorf("DrivePermissions.ListInDrive returned no permissions")
+	}
+}
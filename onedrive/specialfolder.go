@@ -15,8 +15,16 @@ const (
 	CameraRoll
 	AppRoot
 	Music
+	Recordings
 )
 
+var specialFolderNames = [...]string{"documents", "photos", "cameraroll", "approot", "music", "recordings"}
+
+// toString returns the name of the special folder, or an empty string if it is not a valid special folder.
 func (specialFolder DriveSpecialFolder) toString() string {
-	return [...]string{"documents", "photos", "cameraroll", "approot", "music"}[specialFolder]
+	if specialFolder < 0 || int(specialFolder) >= len(specialFolderNames) {
+		return ""
+	}
+
+	return specialFolderNames[specialFolder]
 }
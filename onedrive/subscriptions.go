@@ -0,0 +1,173 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SubscriptionsService handles communication with the change notification subscriptions related methods of the Microsoft Graph API.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/using-webhooks?view=odsp-graph-online
+type SubscriptionsService service
+
+// Subscription represents a subscription which allows a client app to receive notifications about changes to a drive.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/subscription?view=graph-rest-1.0
+type Subscription struct {
+	Id                 string `json:"id,omitempty"`
+	ChangeType         string `json:"changeType,omitempty"`
+	NotificationURL    string `json:"notificationUrl,omitempty"`
+	Resource           string `json:"resource,omitempty"`
+	ExpirationDateTime string `json:"expirationDateTime,omitempty"`
+	ClientState        string `json:"clientState,omitempty"`
+}
+
+// OneDriveSubscriptionsResponse represents the JSON object containing subscription list returned by the Microsoft Graph API.
+type OneDriveSubscriptionsResponse struct {
+	ODataContext  string          `json:"@odata.context"`
+	Subscriptions []*Subscription `json:"value"`
+}
+
+// RenewSubscriptionRequest represents the information needed of renewing a subscription.
+type RenewSubscriptionRequest struct {
+	ExpirationDateTime string `json:"expirationDateTime"`
+}
+
+// Create a subscription to receive notifications about changes to the root of a drive of the authenticated user.
+// The notifications will be sent to notificationUrl, which must be able to answer the validation request
+// (see WebhookHandler) before the subscription is created.
+//
+// The clientState will be included in each notification so that the notifications can be verified.
+// For drive items, the subscription can last for at most 30 days before it must be renewed.
+//
+// If driveId is empty, it means the selected drive will be the default drive of
+// the authenticated user.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/api/subscription_create?view=odsp-graph-online
+func (s *SubscriptionsService) Create(ctx context.Context, driveId string, notificationUrl string, clientState string, expirationDateTime time.Time) (*Subscription, error) {
+	if notificationUrl == "" {
+		return nil, errors.New("Please provide the URL which will receive the notifications.")
+	}
+
+	if expirationDateTime.IsZero() {
+		return nil, errors.New("Please provide the expiration date time of the subscription.")
+	}
+
+	newSubscription := &Subscription{
+		ChangeType:         "updated",
+		NotificationURL:    notificationUrl,
+		Resource:           s.client.driveURL(driveId) + "/root",
+		ExpirationDateTime: expirationDateTime.UTC().Format(time.RFC3339),
+		ClientState:        clientState,
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "subscriptions", newSubscription)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription *Subscription
+	err = s.client.Do(ctx, req, false, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Renew a subscription by extending its expiration date time.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/subscription-update?view=graph-rest-1.0
+func (s *SubscriptionsService) Renew(ctx context.Context, subscriptionId string, expirationDateTime time.Time) (*Subscription, error) {
+	if subscriptionId == "" {
+		return nil, errors.New("Please provide the ID of the subscription to be renewed.")
+	}
+
+	if expirationDateTime.IsZero() {
+		return nil, errors.New("Please provide the new expiration date time of the subscription.")
+	}
+
+	renewSubscriptionRequest := &RenewSubscriptionRequest{
+		ExpirationDateTime: expirationDateTime.UTC().Format(time.RFC3339),
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, "subscriptions/"+url.PathEscape(subscriptionId), renewSubscriptionRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription *Subscription
+	err = s.client.Do(ctx, req, false, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Get a subscription.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/subscription-get?view=graph-rest-1.0
+func (s *SubscriptionsService) Get(ctx context.Context, subscriptionId string) (*Subscription, error) {
+	if subscriptionId == "" {
+		return nil, errors.New("Please provide the ID of the subscription.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, "subscriptions/"+url.PathEscape(subscriptionId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription *Subscription
+	err = s.client.Do(ctx, req, false, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// List the active subscriptions of the calling application.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/subscription-list?view=graph-rest-1.0
+func (s *SubscriptionsService) List(ctx context.Context) (*OneDriveSubscriptionsResponse, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "subscriptions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveSubscriptionsResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// Delete a subscription so that no more notifications will be sent for it.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/graph/api/subscription-delete?view=graph-rest-1.0
+func (s *SubscriptionsService) Delete(ctx context.Context, subscriptionId string) error {
+	if subscriptionId == "" {
+		return errors.New("Please provide the ID of the subscription to be deleted.")
+	}
+
+	req, err := s.client.NewRequest(http.MethodDelete, "subscriptions/"+url.PathEscape(subscriptionId), nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Do(ctx, req, false, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
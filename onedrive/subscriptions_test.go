@@ -0,0 +1,133 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionsService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_subscription.json")
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var subscriptionRequest *Subscription
+		json.NewDecoder(r.Body).Decode(&subscriptionRequest)
+
+		wantSubscriptionRequest := &Subscription{
+			ChangeType:         "updated",
+			NotificationURL:    "https://contoso.com/notifications",
+			Resource:           "me/drive/root",
+			ExpirationDateTime: "2021-01-01T11:00:00Z",
+			ClientState:        "client-specific string",
+		}
+		if !reflect.DeepEqual(subscriptionRequest, wantSubscriptionRequest) {
+			t.Errorf("Request body = %+v, want %+v", subscriptionRequest, wantSubscriptionRequest)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotSubscription, err := client.Subscriptions.Create(ctx, "", "https://contoso.com/notifications", "client-specific string", time.Date(2021, 1, 1, 11, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Errorf("Subscriptions.Create returned error: %v", err)
+	}
+
+	var wantSubscription *Subscription
+	json.Unmarshal(jsonData, &wantSubscription)
+
+	if !reflect.DeepEqual(gotSubscription, wantSubscription) {
+		t.Errorf("Subscriptions.Create returned %+v, want %+v", gotSubscription, wantSubscription)
+	}
+}
+
+func TestSubscriptionsService_Renew(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_subscription.json")
+	mux.HandleFunc("/subscriptions/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+
+		var renewSubscriptionRequest *RenewSubscriptionRequest
+		json.NewDecoder(r.Body).Decode(&renewSubscriptionRequest)
+
+		if renewSubscriptionRequest.ExpirationDateTime != "2021-01-01T11:00:00Z" {
+			t.Errorf("Request body = %+v, want the new expiration date time", renewSubscriptionRequest)
+		}
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	_, err := client.Subscriptions.Renew(ctx, "1", time.Date(2021, 1, 1, 19, 0, 0, 0, time.FixedZone("SGT", 8*60*60)))
+	if err != nil {
+		t.Errorf("Subscriptions.Renew returned error: %v", err)
+	}
+}
+
+func TestSubscriptionsService_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_subscriptions.json")
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, string(jsonData))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.Subscriptions.List(ctx)
+	if err != nil {
+		t.Errorf("Subscriptions.List returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveSubscriptionsResponse
+	json.Unmarshal(jsonData, &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("Subscriptions.List returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+}
+
+func TestSubscriptionsService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	isDeleteRequestSent := false
+	mux.HandleFunc("/subscriptions/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+
+		isDeleteRequestSent = true
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	err := client.Subscriptions.Delete(ctx, "1")
+	if err != nil {
+		t.Errorf("Subscriptions.Delete returned error: %v", err)
+	}
+
+	if !isDeleteRequestSent {
+		t.Errorf("Subscriptions.Delete did not send the DELETE request")
+	}
+}
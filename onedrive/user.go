@@ -6,21 +6,35 @@ package onedrive
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
 )
 
 type UserService service
 
 // User represents an user in Microsoft Live.
 type User struct {
-	Id          string `json:"id"`
-	DisplayName string `json:"displayName"`
-	Email       string `json:"mail"`
-	
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	Email             string `json:"mail"`
+	UserPrincipalName string `json:"userPrincipalName,omitempty"`
 }
 
+// OneDriveUsersResponse represents the JSON object containing user list returned by the OneDrive API.
+type OneDriveUsersResponse struct {
+	ODataContext string  `json:"@odata.context"`
+	NextLink     string  `json:"@odata.nextLink"`
+	Users        []*User `json:"value"`
+}
+
+// GetCurrentUserDetails gets the details of the user of the client, which is the authenticated user
+// unless the client is returned by Client.ForUser.
+//
 // OneDrive API docs: https://learn.microsoft.com/en-us/graph/api/user-get?view=graph-rest-1.0&tabs=http
 func (s *UserService) GetCurrentUserDetails(ctx context.Context) (*User, error) {
-	apiURL := "me"
+	apiURL := s.client.userURL
 
 	req, err := s.client.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -35,3 +49,71 @@ func (s *UserService) GetCurrentUserDetails(ctx context.Context) (*User, error)
 
 	return oneDriveResponse, nil
 }
+
+// Get the details of a user by the user ID or the user principal name.
+//
+// OneDrive API docs: https://learn.microsoft.com/en-us/graph/api/user-get?view=graph-rest-1.0&tabs=http
+func (s *UserService) Get(ctx context.Context, userIdOrUPN string) (*User, error) {
+	if userIdOrUPN == "" {
+		return nil, errors.New("Please provide the ID or the user principal name of the user.")
+	}
+
+	req, err := s.client.NewRequest("GET", "users/"+url.PathEscape(userIdOrUPN), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *User
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
+
+// List the users in the organization. This requires the User.Read.All or the User.ReadBasic.All permission.
+//
+// If pageSize is positive, it is the number of users returned in each page, up to 999. When the returned
+// NextLink is not empty, the next page can be retrieved with ListNext.
+//
+// OneDrive API docs: https://learn.microsoft.com/en-us/graph/api/user-list?view=graph-rest-1.0&tabs=http
+func (s *UserService) List(ctx context.Context, pageSize int) (*OneDriveUsersResponse, error) {
+	apiURL := "users?$select=id,displayName,mail,userPrincipalName"
+	if pageSize > 0 {
+		apiURL += "&$top=" + strconv.Itoa(pageSize)
+	}
+
+	return s.list(ctx, apiURL)
+}
+
+// ListNext lists the next page of the users with the NextLink returned by List or ListNext.
+// The next link must point to the host of the BaseURL of the client.
+//
+// OneDrive API docs: https://learn.microsoft.com/en-us/graph/api/user-list?view=graph-rest-1.0&tabs=http
+func (s *UserService) ListNext(ctx context.Context, nextLink string) (*OneDriveUsersResponse, error) {
+	if nextLink == "" {
+		return nil, errors.New("Please provide the next link of the user list.")
+	}
+
+	if !s.client.isAPIURL(nextLink) {
+		return nil, fmt.Errorf("The given next link %q is not a URL of the OneDrive API.", nextLink)
+	}
+
+	return s.list(ctx, nextLink)
+}
+
+func (s *UserService) list(ctx context.Context, apiURL string) (*OneDriveUsersResponse, error) {
+	req, err := s.client.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var oneDriveResponse *OneDriveUsersResponse
+	err = s.client.Do(ctx, req, false, &oneDriveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneDriveResponse, nil
+}
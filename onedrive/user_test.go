@@ -0,0 +1,90 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUserService_List(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	defer teardown()
+
+	jsonData := getTestDataFromFile(t, "fake_users.json")
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if r.FormValue("$skiptoken") == "page2" {
+			fmt.Fprint(w, `{"value": [{"id": "user3", "userPrincipalName": "megan@contoso.com"}]}`)
+			return
+		}
+
+		testFormValues(t, r, map[string]string{"$top": "2", "$select": "id,displayName,mail,userPrincipalName"})
+
+		fmt.Fprint(w, strings.Replace(string(jsonData), "{baseURL}", serverURL+baseURLPath, -1))
+	})
+
+	ctx := context.Background()
+	gotOneDriveResponse, err := client.User.List(ctx, 2)
+	if err != nil {
+		t.Fatalf("User.List returned error: %v", err)
+	}
+
+	var wantOneDriveResponse *OneDriveUsersResponse
+	json.Unmarshal([]byte(strings.Replace(string(jsonData), "{baseURL}", serverURL+baseURLPath, -1)), &wantOneDriveResponse)
+
+	if !reflect.DeepEqual(gotOneDriveResponse, wantOneDriveResponse) {
+		t.Errorf("User.List returned %+v, want %+v", gotOneDriveResponse, wantOneDriveResponse)
+	}
+
+	gotNextPage, err := client.User.ListNext(ctx, gotOneDriveResponse.NextLink)
+	if err != nil {
+		t.Fatalf("User.ListNext returned error: %v", err)
+	}
+
+	if len(gotNextPage.Users) != 1 || gotNextPage.NextLink != "" {
+		t.Errorf("User.ListNext returned %+v, want the last page with one user", gotNextPage)
+	}
+
+	for _, nextLink := range []string{"https://attacker.example.com/v1.0/users?$skiptoken=X", "//attacker.example.com/users", "https://user@" + client.BaseURL.Host + "/users"} {
+		if _, err := client.User.ListNext(ctx, nextLink); err == nil {
+			t.Errorf("User.ListNext returned no error for the next link %q", nextLink)
+		}
+	}
+}
+
+func TestUserService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/users/adele@contoso.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		fmt.Fprint(w, `{"id": "user1", "displayName": "Adele Vance", "mail": "adele@contoso.com", "userPrincipalName": "adele@contoso.com"}`)
+	})
+
+	ctx := context.Background()
+	gotUser, err := client.User.Get(ctx, "adele@contoso.com")
+	if err != nil {
+		t.Fatalf("User.Get returned error: %v", err)
+	}
+
+	wantUser := &User{Id: "user1", DisplayName: "Adele Vance", Email: "adele@contoso.com", UserPrincipalName: "adele@contoso.com"}
+	if !reflect.DeepEqual(gotUser, wantUser) {
+		t.Errorf("User.Get returned %+v, want %+v", gotUser, wantUser)
+	}
+
+	if _, err := client.User.Get(ctx, ""); err == nil {
+		t.Errorf("User.Get returned no error for an empty user ID")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// maxNotificationBodySize is the maximum size of a notification request body accepted by WebhookHandler.
+const maxNotificationBodySize = 1 << 20
+
+// ChangeNotification represents a notification about changes to the resource of a subscription.
+// For drive items, the notification only tells that something has changed in the drive. The actual
+// changes can be retrieved with the delta API.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/changenotification?view=graph-rest-1.0
+type ChangeNotification struct {
+	SubscriptionId                 string `json:"subscriptionId"`
+	SubscriptionExpirationDateTime string `json:"subscriptionExpirationDateTime"`
+	ClientState                    string `json:"clientState"`
+	ChangeType                     string `json:"changeType"`
+	Resource                       string `json:"resource"`
+	TenantId                       string `json:"tenantId"`
+}
+
+// ChangeNotificationCollection represents the batch of notifications sent to the notification URL of a subscription.
+// Ref: https://docs.microsoft.com/en-us/graph/api/resources/changenotificationcollection?view=graph-rest-1.0
+type ChangeNotificationCollection struct {
+	Value []*ChangeNotification `json:"value"`
+}
+
+// WebhookHandler is an http.Handler which receives the notifications sent to the notification URL of a subscription.
+//
+// It answers the validation request sent by Microsoft Graph when a subscription is created, and it drops the
+// notifications which do not carry the expected client state. The remaining notifications of each batch are
+// handed to OnNotifications and then sent to Notifications, whichever is set.
+//
+// Microsoft Graph expects a response within a few seconds. Hence, OnNotifications should return quickly and
+// Notifications should be drained promptly.
+//
+// OneDrive API docs: https://docs.microsoft.com/en-us/onedrive/developer/rest-api/concepts/using-webhooks?view=odsp-graph-online
+type WebhookHandler struct {
+	// ClientState is the client state given when creating the subscriptions. If it is empty,
+	// notifications will not be verified.
+	ClientState string
+
+	// OnNotifications is called with the verified notifications of each batch.
+	OnNotifications func(notifications []*ChangeNotification)
+
+	// Notifications receives each of the verified notifications.
+	Notifications chan<- *ChangeNotification
+}
+
+// NewWebhookHandler returns a new WebhookHandler which hands the verified notifications to the given callback.
+func NewWebhookHandler(clientState string, onNotifications func(notifications []*ChangeNotification)) *WebhookHandler {
+	return &WebhookHandler{
+		ClientState:     clientState,
+		OnNotifications: onNotifications,
+	}
+}
+
+// ServeHTTP handles the validation requests and the notification requests from Microsoft Graph.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// When a subscription is created, Microsoft Graph validates the notification URL by sending a
+	// validation token, which must be returned as plain text within 10 seconds.
+	if validationToken := r.URL.Query().Get("validationToken"); validationToken != "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, validationToken)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var notificationCollection *ChangeNotificationCollection
+	err := json.NewDecoder(io.LimitReader(r.Body, maxNotificationBodySize)).Decode(&notificationCollection)
+	if err != nil || notificationCollection == nil {
+		http.Error(w, "Invalid notification payload.", http.StatusBadRequest)
+		return
+	}
+
+	var notifications []*ChangeNotification
+	for _, notification := range notificationCollection.Value {
+		if notification != nil && h.isValid(notification) {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	if len(notificationCollection.Value) > 0 && len(notifications) == 0 {
+		http.Error(w, "Invalid client state.", http.StatusBadRequest)
+		return
+	}
+
+	if len(notifications) > 0 && h.OnNotifications != nil {
+		h.OnNotifications(notifications)
+	}
+
+	if h.Notifications != nil {
+		for _, notification := range notifications {
+			select {
+			case h.Notifications <- notification:
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// isValid reports whether the given notification carries the expected client state.
+func (h *WebhookHandler) isValid(notification *ChangeNotification) bool {
+	if h.ClientState == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(notification.ClientState), []byte(h.ClientState)) == 1
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 The go-onedrive AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a license that can be found in the LICENSE file.
+
+package onedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler_Validation(t *testing.T) {
+	handler := NewWebhookHandler("secret", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications?validationToken="+url.QueryEscape("Validation: Testing client application reachability"), nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("WebhookHandler returned status %v, want %v", recorder.Code, http.StatusOK)
+	}
+
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("WebhookHandler returned Content-Type %q, want text/plain", got)
+	}
+
+	if got := recorder.Body.String(); got != "Validation: Testing client application reachability" {
+		t.Errorf("WebhookHandler returned %q, want the validation token", got)
+	}
+}
+
+func TestWebhookHandler_InvalidPayload(t *testing.T) {
+	handler := NewWebhookHandler("secret", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader("{"))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("WebhookHandler returned status %v, want %v", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+func TestWebhookHandler_ClientState(t *testing.T) {
+	var gotNotifications []*ChangeNotification
+	handler := NewWebhookHandler("secret", func(notifications []*ChangeNotification) {
+		gotNotifications = append(gotNotifications, notifications...)
+	})
+
+	body := `{"value": [
+		{"subscriptionId": "1", "clientState": "secret", "changeType": "updated", "resource": "me/drive/root"},
+		{"subscriptionId": "2", "clientState": "forged", "changeType": "updated", "resource": "me/drive/root"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusAccepted {
+		t.Errorf("WebhookHandler returned status %v, want %v", recorder.Code, http.StatusAccepted)
+	}
+
+	if len(gotNotifications) != 1 || gotNotifications[0].SubscriptionId != "1" {
+		t.Errorf("WebhookHandler handed %+v, want only the notification with the expected client state", gotNotifications)
+	}
+
+	// A batch without any notification carrying the expected client state is rejected.
+	body = `{"value": [{"subscriptionId": "2", "clientState": "forged"}]}`
+	req = httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(body))
+	recorder = httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("WebhookHandler returned status %v, want %v", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+// TestWebhookHandler_EndToEnd creates a subscription against a fake Microsoft Graph which, like the real one,
+// validates the notification URL before creating the subscription and then sends a notification to it.
+func TestWebhookHandler_EndToEnd(t *testing.T) {
+	notifications := make(chan *ChangeNotification, 1)
+	webhookServer := httptest.NewServer(&WebhookHandler{
+		ClientState:   "secret",
+		Notifications: notifications,
+	})
+	defer webhookServer.Close()
+
+	client, mux, _, teardown := setup()
+
+	defer teardown()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var subscription *Subscription
+		json.NewDecoder(r.Body).Decode(&subscription)
+
+		validationResp, err := http.Post(subscription.NotificationURL+"?validationToken=token123", "text/plain", nil)
+		if err != nil {
+			t.Errorf("Validation request returned error: %v", err)
+			return
+		}
+		validationToken, _ := ioutil.ReadAll(validationResp.Body)
+		validationResp.Body.Close()
+
+		if string(validationToken) != "token123" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": "InvalidRequest", "message": "Subscription validation request failed."}}`)
+			return
+		}
+
+		subscription.Id = "1"
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(subscription)
+
+		go func() {
+			notificationBody := `{"value": [{"subscriptionId": "1", "clientState": "` + subscription.ClientState + `", "changeType": "updated", "resource": "` + subscription.Resource + `"}]}`
+			notificationResp, err := http.Post(subscription.NotificationURL, "application/json", strings.NewReader(notificationBody))
+			if err != nil {
+				t.Errorf("Notification request returned error: %v", err)
+				return
+			}
+			notificationResp.Body.Close()
+
+			if notificationResp.StatusCode != http.StatusAccepted {
+				t.Errorf("Notification request returned status %v, want %v", notificationResp.StatusCode, http.StatusAccepted)
+			}
+		}()
+	})
+
+	ctx := context.Background()
+	subscription, err := client.Subscriptions.Create(ctx, "drive1", webhookServer.URL, "secret", time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Subscriptions.Create returned error: %v", err)
+	}
+
+	select {
+	case notification := <-notifications:
+		if notification.SubscriptionId != subscription.Id || notification.Resource != "drives/drive1/root" {
+			t.Errorf("WebhookHandler received %+v, want a notification of subscription %q", notification, subscription.Id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("WebhookHandler did not receive the notification")
+	}
+}